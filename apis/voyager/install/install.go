@@ -30,7 +30,7 @@ func Install(groupFactoryRegistry announced.APIGroupFactoryRegistry, registry *r
 	if err := announced.NewGroupMetaFactory(
 		&announced.GroupMetaFactoryArgs{
 			GroupName:              voyager.GroupName,
-			RootScopedKinds:        sets.NewString(),
+			RootScopedKinds:        sets.NewString(v1beta1.ResourceKindClusterIssuer),
 			VersionPreferenceOrder: []string{v1beta1.SchemeGroupVersion.Version},
 		},
 		announced.VersionToSchemeFunc{
@@ -2,3 +2,8 @@ package voyager
 
 // GroupName is the group name use in this package
 const GroupName = "voyager.appscode.com"
+
+// EnableStatusSubresource is true, if the status of Ingresses is updated via the status subresource.
+// The operator and haproxy-controller set it on Kubernetes 1.11 or later, so that status updates do
+// not change metadata.generation.
+var EnableStatusSubresource bool
@@ -89,6 +89,9 @@ const (
 	// Annotations applied to resources offshoot from an ingress
 	OriginAPISchema = EngressKey + "/" + "origin-api-schema" // APISchema = {APIGroup}/{APIVersion}
 	OriginName      = EngressKey + "/" + "origin-name"
+	// Hash of the default certificate of operator, set on the offshoot ConfigMap so that HAProxy pods
+	// read the certificate again when it changes
+	DefaultCertificateHash = EngressKey + "/" + "default-certificate-hash"
 
 	// https://github.com/appscode/voyager/issues/280
 	// Supports all valid timeout option for defaults section of HAProxy
@@ -220,6 +223,15 @@ const (
 	SSLRedirect      = EngressKey + "/ssl-redirect"
 	ForceSSLRedirect = EngressKey + "/force-ssl-redirect"
 
+	// If set, haproxy sidecar fetches OCSP responses for served certificates and staples them
+	// https://cbonte.github.io/haproxy-dconv/1.8/management.html#9.3-set%20ssl%20ocsp-response
+	OCSPStapling = EngressKey + "/ocsp-stapling"
+
+	// Name of the ACME user Secret used to issue certificates for the hosts in spec.tls.
+	// If set, operator creates a Certificate for each spec.tls entry, verifies its hosts via
+	// http-01 challenge through this Ingress and deletes the Certificate when the entry is removed.
+	ACMEIssuerSecret = EngressKey + "/acme-issuer"
+
 	// https://github.com/appscode/voyager/issues/525
 	ErrorFiles = EngressKey + "/errorfiles"
 
@@ -273,6 +285,7 @@ func init() {
 	registerParser(RewriteTarget, meta.GetString)
 	registerParser(AuthRealm, meta.GetString)
 	registerParser(AuthTLSSecret, meta.GetString)
+	registerParser(ACMEIssuerSecret, meta.GetString)
 	registerParser(AuthTLSVerifyClient, meta.GetString)
 	registerParser(AuthTLSErrorPage, meta.GetString)
 	registerParser(ErrorFiles, meta.GetString)
@@ -283,6 +296,7 @@ func init() {
 	registerParser(HSTSIncludeSubDomains, meta.GetBool)
 	registerParser(SSLRedirect, meta.GetBool)
 	registerParser(ForceSSLRedirect, meta.GetBool)
+	registerParser(OCSPStapling, meta.GetBool)
 	registerParser(SSLPassthrough, meta.GetBool)
 	registerParser(StatsOn, meta.GetBool)
 	registerParser(KeepSourceIP, meta.GetBool)
@@ -305,6 +319,15 @@ const (
 	ACMEUserPrivatekey   = "ACME_USER_PRIVATE_KEY"
 	ACMERegistrationData = "ACME_REGISTRATION_DATA"
 	ACMEServerURL        = "ACME_SERVER_URL"
+	// ACMEServerCABundle is the PEM encoded CA bundle used to verify a private ACME server
+	ACMEServerCABundle = "ACME_CA_BUNDLE"
+	// ACMEExternalAccountKeyID and ACMEExternalAccountHMACKey bind new ACME accounts to an account of the CA
+	ACMEExternalAccountKeyID   = "ACME_EAB_KEY_ID"
+	ACMEExternalAccountHMACKey = "ACME_EAB_HMAC_KEY"
+
+	// VaultAppRoleID and VaultAppRoleSecretID are the keys of the Secret used for Vault approle auth method
+	VaultAppRoleID       = "role_id"
+	VaultAppRoleSecretID = "secret_id"
 )
 
 type ProxyProtocolVersion string
@@ -484,6 +507,11 @@ func (r Ingress) ForceSSLRedirect() bool {
 	return v.(bool)
 }
 
+func (r Ingress) OCSPStapling() bool {
+	v, _ := get[OCSPStapling](r.Annotations)
+	return v.(bool)
+}
+
 func (r Ingress) ProxyBodySize() string {
 	v, _ := get[ProxyBodySize](r.Annotations)
 	return v.(string)
@@ -511,6 +539,13 @@ func (r Ingress) StatsPort() int {
 	return DefaultStatsPort
 }
 
+// DefaultCertificateRoleName is the name of the Role and RoleBinding that allow HAProxy pods of this
+// Ingress to read the default certificate of operator. Those live in the namespace of the certificate,
+// so the name includes the namespace of Ingress. Namespaces can't contain '.', so names never collide.
+func (r Ingress) DefaultCertificateRoleName() string {
+	return VoyagerPrefix + r.Namespace + "." + r.Name + "-default-tls"
+}
+
 func (r Ingress) StatsServiceName() string {
 	/*if v, _ := parser[StatsServiceName](r.Annotations, StatsServiceName); v != "" {
 		return v.(string)
@@ -692,6 +727,11 @@ func (r Ingress) AuthTLSSecret() string {
 	return v.(string)
 }
 
+func (r Ingress) ACMEIssuerSecret() string {
+	v, _ := get[ACMEIssuerSecret](r.Annotations)
+	return v.(string)
+}
+
 func (r Ingress) AuthTLSVerifyClient() TLSAuthVerifyOption {
 	if v, _ := get[AuthTLSVerifyClient](r.Annotations); v == string(TLSAuthVerifyOptional) {
 		return TLSAuthVerifyOptional
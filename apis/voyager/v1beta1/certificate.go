@@ -1,6 +1,8 @@
 package v1beta1
 
 import (
+	"time"
+
 	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -11,6 +13,52 @@ const (
 	ResourcePluralCertificate   = "certificates"
 )
 
+const (
+	// Keys of an optional ECDSA certificate in a tls secret. It is served along with the RSA certificate
+	// in tls.crt and tls.key as a HAProxy multi-cert bundle, so clients negotiate the best one.
+	// ref: https://cbonte.github.io/haproxy-dconv/1.8/configuration.html#5.1-crt
+	TLSECDSACertKey       = "tls-ecdsa.crt"
+	TLSECDSAPrivateKeyKey = "tls-ecdsa.key"
+)
+
+const (
+	// DefaultRenewBefore is how long before expiry certificates are renewed, unless spec.renewBefore is set.
+	DefaultRenewBefore = 7 * 24 * time.Hour
+	// DefaultCertificateDuration is the lifetime of certificates signed by selfSigned and ca issuers,
+	// unless spec.duration is set.
+	DefaultCertificateDuration = 90 * 24 * time.Hour
+)
+
+type KeyAlgorithm string
+
+const (
+	KeyAlgorithmRSA   KeyAlgorithm = "rsa"
+	KeyAlgorithmECDSA KeyAlgorithm = "ecdsa"
+)
+
+// RevocationReason is the reason of revoking a certificate, from RFC 5280 section 5.3.1.
+type RevocationReason string
+
+const (
+	RevocationReasonUnspecified          RevocationReason = "Unspecified"
+	RevocationReasonKeyCompromise        RevocationReason = "KeyCompromise"
+	RevocationReasonAffiliationChanged   RevocationReason = "AffiliationChanged"
+	RevocationReasonSuperseded           RevocationReason = "Superseded"
+	RevocationReasonCessationOfOperation RevocationReason = "CessationOfOperation"
+)
+
+// CertificateDeletionPolicy decides what happens to the stored certificate when a Certificate is deleted.
+type CertificateDeletionPolicy string
+
+const (
+	// CertificateDeletionPolicyRetain keeps the stored certificate.
+	CertificateDeletionPolicyRetain CertificateDeletionPolicy = "Retain"
+	// CertificateDeletionPolicyDelete deletes the stored certificate from its Secret or Vault.
+	CertificateDeletionPolicyDelete CertificateDeletionPolicy = "Delete"
+	// CertificateDeletionPolicyRevokeAndDelete revokes the certificate at the ACME server before deleting it.
+	CertificateDeletionPolicyRevokeAndDelete CertificateDeletionPolicy = "RevokeAndDelete"
+)
+
 // +genclient
 // +k8s:openapi-gen=true
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -28,8 +76,19 @@ type CertificateSpec struct {
 	// domains are added using the Subject Alternate Names extension.
 	Domains []string `json:"domains,omitempty"`
 
-	// ChallengeProvider details to verify domains
-	ChallengeProvider ChallengeProvider `json:"challengeProvider"`
+	// Issuer signs the certificate. Default is acme.
+	// +optional
+	Issuer *CertificateIssuer `json:"issuer,omitempty"`
+
+	// Duration is the requested lifetime of certificates signed by selfSigned, ca and vault issuers.
+	// Default is 2160h (90 days), or the ttl of the PKI role for vault issuer.
+	// ACME servers choose the lifetime of their certificates.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// ChallengeProvider details to verify domains. Required for acme issuer.
+	// +optional
+	ChallengeProvider ChallengeProvider `json:"challengeProvider,omitempty"`
 
 	// Secret contains ACMEUser information. Secret must contain a key `email`
 	// If empty tries to find an Secret via domains
@@ -39,11 +98,50 @@ type CertificateSpec struct {
 	//  ACME_SERVER_URL -> custom server url to generate certificates, default is lets encrypt.
 	//  ACME_USER_DATA -> user data, if not found one will be created for the provided email,
 	//    and stored in the key.
-	ACMEUserSecretName string `json:"acmeUserSecretName"`
+	// Required for acme issuer.
+	// +optional
+	ACMEUserSecretName string `json:"acmeUserSecretName,omitempty"`
 
 	// Storage backend to store the certificates currently, kubernetes secret and vault.
 	Storage CertificateStorage `json:"storage,omitempty"`
 
+	// DualKey issues an ECDSA (P-256) certificate for the same domains along with the RSA certificate.
+	// The ECDSA certificate is stored under tls-ecdsa.crt and tls-ecdsa.key keys.
+	// +optional
+	DualKey bool `json:"dualKey,omitempty"`
+
+	// KeyAlgorithm is the algorithm of the certificate private key, rsa or ecdsa. Default is rsa.
+	// For dual key Certificates, it applies to the primary certificate and must be rsa.
+	// +optional
+	KeyAlgorithm KeyAlgorithm `json:"keyAlgorithm,omitempty"`
+
+	// KeySize is the size of the certificate private key in bits.
+	// Supported sizes are 2048 (default) and 4096 for rsa, 256 (default) and 384 for ecdsa.
+	// +optional
+	KeySize int `json:"keySize,omitempty"`
+
+	// ReuseKey keeps the existing private key when the certificate is renewed.
+	// By default, a new private key is generated on each renewal.
+	// +optional
+	ReuseKey bool `json:"reuseKey,omitempty"`
+
+	// RenewBefore is how long before expiry the certificate is renewed. It is either a duration,
+	// like 720h, or a percentage of the certificate lifetime, like 33%. Default is 168h (7 days).
+	// If the duration is not shorter than the certificate lifetime, the certificate is renewed
+	// after two thirds of its lifetime.
+	// +optional
+	RenewBefore string `json:"renewBefore,omitempty"`
+
+	// Revoke revokes the current certificate at the ACME server. A revoked certificate is not renewed,
+	// unless revoke.reissue is set. Remove it to issue a new certificate.
+	// +optional
+	Revoke *CertificateRevocation `json:"revoke,omitempty"`
+
+	// DeletionPolicy is one of Retain (default), Delete and RevokeAndDelete. With Delete and RevokeAndDelete,
+	// the stored certificate and the ACME user secret, if no longer used, are removed when the Certificate is deleted.
+	// +optional
+	DeletionPolicy CertificateDeletionPolicy `json:"deletionPolicy,omitempty"`
+
 	// Following fields are deprecated and will removed in future version.
 	// https://github.com/appscode/voyager/pull/506
 	// Deprecated. DNS Provider.
@@ -65,6 +163,60 @@ type CertificateSpec struct {
 	ACMEServerURL string `json:"acmeStagingURL,omitempty"`
 }
 
+// CertificateIssuer selects the issuer of a certificate. Only one of its fields must be set.
+type CertificateIssuer struct {
+	// ACME issues the certificate from an ACME server like Let's Encrypt,
+	// using spec.challengeProvider and spec.acmeUserSecretName.
+	ACME *ACMEIssuer `json:"acme,omitempty"`
+	// SelfSigned signs the certificate with its own private key.
+	SelfSigned *SelfSignedIssuer `json:"selfSigned,omitempty"`
+	// CA signs the certificate with a CA certificate and private key.
+	CA *CAIssuer `json:"ca,omitempty"`
+	// Vault issues the certificate from a Vault PKI secrets engine.
+	Vault *VaultIssuer `json:"vault,omitempty"`
+}
+
+type ACMEIssuer struct {
+	// PreferredChain selects the certificate chain whose top-most certificate is issued by this common name,
+	// ie, "ISRG Root X1", if the ACME server offers alternate chains. Otherwise the default chain is used.
+	PreferredChain string `json:"preferredChain,omitempty"`
+
+	// SkipSelfCheck submits challenges to the ACME server without checking first that they are
+	// reachable from operator. Use it if operator can't reach the Ingress via its public address.
+	// +optional
+	SkipSelfCheck bool `json:"skipSelfCheck,omitempty"`
+
+	// ClusterIssuer is the name of a ClusterIssuer whose ACME account is used. Its challenge provider
+	// is used, unless spec.challengeProvider is set. spec.acmeUserSecretName is ignored.
+	// +optional
+	ClusterIssuer string `json:"clusterIssuer,omitempty"`
+}
+
+type SelfSignedIssuer struct{}
+
+type CAIssuer struct {
+	// SecretName is the name of the Secret holding the CA certificate and private key
+	// in tls.crt and tls.key. The Secret must be in the namespace of the Certificate.
+	SecretName string `json:"secretName"`
+}
+
+type VaultIssuer struct {
+	// Path is the mount path of the PKI secrets engine. Default is pki.
+	Path string `json:"path,omitempty"`
+	// Role is the name of the PKI role used to issue the certificate.
+	Role string `json:"role"`
+}
+
+type CertificateRevocation struct {
+	// Reason is sent to the ACME server with the revocation request. One of Unspecified (default),
+	// KeyCompromise, AffiliationChanged, Superseded and CessationOfOperation.
+	// +optional
+	Reason RevocationReason `json:"reason,omitempty"`
+	// Reissue issues a new certificate with a fresh private key right after revocation.
+	// +optional
+	Reissue bool `json:"reissue,omitempty"`
+}
+
 type ChallengeProvider struct {
 	HTTP *HTTPChallengeProvider `json:"http,omitempty"`
 	DNS  *DNSChallengeProvider  `json:"dns,omitempty"`
@@ -78,6 +230,27 @@ type DNSChallengeProvider struct {
 	// DNS Provider from the list https://github.com/appscode/voyager/blob/master/docs/tasks/certificate/providers.md
 	Provider             string `json:"provider,omitempty"`
 	CredentialSecretName string `json:"credentialSecretName,omitempty"`
+	// Webhook is the endpoint called to present and clean up TXT records. Required for webhook provider.
+	// +optional
+	Webhook *DNSWebhook `json:"webhook,omitempty"`
+}
+
+// DNSWebhook is the endpoint of a webhook dns provider. Exactly one of url and service must be set.
+type DNSWebhook struct {
+	// URL of the webhook, ie, https://dns.example.com/acme
+	URL string `json:"url,omitempty"`
+	// Service refers to a Service in the namespace of the Certificate that serves the webhook.
+	Service *DNSWebhookService `json:"service,omitempty"`
+}
+
+type DNSWebhookService struct {
+	Name string `json:"name"`
+	// Port of the Service. Default is 443.
+	Port int32 `json:"port,omitempty"`
+	// Path of the webhook in the Service, ie, /acme
+	Path string `json:"path,omitempty"`
+	// Scheme is http or https. Default is https.
+	Scheme string `json:"scheme,omitempty"`
 }
 
 type CertificateStorage struct {
@@ -88,12 +261,49 @@ type CertificateStorage struct {
 type VaultStore struct {
 	Name   string `json:"name,omitempty"`
 	Prefix string `json:"prefix,omitempty"`
+	// Namespace is the Vault Enterprise namespace of the secrets. Default is VAULT_NAMESPACE of operator.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Auth is the Vault auth method used to store the certificate. Default is the auth method
+	// configured for operator via VAULT_TOKEN or VAULT_KUBERNETES_ROLE environment variables.
+	// +optional
+	Auth *VaultAuth `json:"auth,omitempty"`
+}
+
+// VaultAuth selects a Vault auth method. Only one of its fields must be set.
+type VaultAuth struct {
+	// Kubernetes logs in with the service account token of operator and haproxy pods.
+	Kubernetes *VaultKubernetesAuth `json:"kubernetes,omitempty"`
+	// AppRole logs in with role_id and secret_id keys of a Secret in the namespace of the Certificate.
+	AppRole *VaultAppRoleAuth `json:"appRole,omitempty"`
+}
+
+type VaultKubernetesAuth struct {
+	// Role is the name of the Vault role bound to the service accounts.
+	Role string `json:"role"`
+	// MountPath of the kubernetes auth method. Default is kubernetes.
+	MountPath string `json:"mountPath,omitempty"`
+}
+
+type VaultAppRoleAuth struct {
+	// SecretName is the name of the Secret holding role_id and secret_id keys.
+	SecretName string `json:"secretName"`
+	// MountPath of the approle auth method. Default is approle.
+	MountPath string `json:"mountPath,omitempty"`
 }
 
 type CertificateStatus struct {
 	CreationTime          *metav1.Time           `json:"creationTime,omitempty"`
 	Conditions            []CertificateCondition `json:"conditions,omitempty"`
 	LastIssuedCertificate *CertificateDetails    `json:"lastIssuedCertificate,omitempty"`
+	// NextRenewalTime is when the last issued certificate is scheduled to be renewed.
+	NextRenewalTime *metav1.Time `json:"nextRenewalTime,omitempty"`
+	// FailureCount is the number of consecutive failed attempts to issue or renew the certificate.
+	FailureCount int32 `json:"failureCount,omitempty"`
+	// NextAttemptTime is when operator retries to issue or renew the certificate after a failure.
+	NextAttemptTime *metav1.Time `json:"nextAttemptTime,omitempty"`
+	// Revocation describes the certificate revoked for spec.revoke. It is cleared when spec.revoke is removed.
+	Revocation *CertificateRevocationStatus `json:"revocation,omitempty"`
 	// Deprecated
 	CertificateObtained bool `json:"certificateObtained,omitempty"`
 	// Deprecated
@@ -112,12 +322,26 @@ type ACMECertificateDetails struct {
 }
 
 type CertificateDetails struct {
-	SerialNumber  string      `json:"serialNumber,omitempty"`
-	NotBefore     metav1.Time `json:"notBefore,omitempty"`
-	NotAfter      metav1.Time `json:"notAfter,omitempty"`
-	CertURL       string      `json:"certURL"`
-	CertStableURL string      `json:"certStableURL"`
-	AccountRef    string      `json:"accountRef,omitempty"`
+	SerialNumber  string       `json:"serialNumber,omitempty"`
+	NotBefore     metav1.Time  `json:"notBefore,omitempty"`
+	NotAfter      metav1.Time  `json:"notAfter,omitempty"`
+	CertURL       string       `json:"certURL"`
+	CertStableURL string       `json:"certStableURL"`
+	AccountRef    string       `json:"accountRef,omitempty"`
+	KeyAlgorithm  KeyAlgorithm `json:"keyAlgorithm,omitempty"`
+	KeySize       int          `json:"keySize,omitempty"`
+	// ChainIssuer is the common name of the issuer of the top-most certificate in the chain.
+	ChainIssuer string `json:"chainIssuer,omitempty"`
+	// ACMEServerURL is the directory url of the ACME server that issued the certificate.
+	ACMEServerURL string `json:"acmeServerURL,omitempty"`
+	// ExternalAccountKeyID is the key id of the external account bound to the ACME account.
+	ExternalAccountKeyID string `json:"externalAccountKeyID,omitempty"`
+}
+
+type CertificateRevocationStatus struct {
+	SerialNumber   string           `json:"serialNumber,omitempty"`
+	Reason         RevocationReason `json:"reason,omitempty"`
+	RevocationTime metav1.Time      `json:"revocationTime,omitempty"`
 }
 
 type RequestConditionType string
@@ -127,6 +351,13 @@ const (
 	CertificateIssued      RequestConditionType = "Issued"
 	CertificateFailed      RequestConditionType = "Failed"
 	CertificateRateLimited RequestConditionType = "RateLimited"
+	CertificateRevoked     RequestConditionType = "Revoked"
+
+	// Self check conditions are reported while operator checks that the challenges are reachable,
+	// before asking the ACME server to validate them.
+	CertificateSelfCheckPending RequestConditionType = "SelfCheckPending"
+	CertificateSelfCheckPassed  RequestConditionType = "SelfCheckPassed"
+	CertificateSelfCheckFailed  RequestConditionType = "SelfCheckFailed"
 )
 
 type CertificateCondition struct {
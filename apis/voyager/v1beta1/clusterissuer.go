@@ -0,0 +1,49 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindClusterIssuer     = "ClusterIssuer"
+	ResourceSingularClusterIssuer = "clusterissuer"
+	ResourcePluralClusterIssuer   = "clusterissuers"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterIssuer holds an ACME account and challenge provider shared by Certificates of many namespaces.
+// Certificates refer to it via spec.issuer.acme.clusterIssuer.
+type ClusterIssuer struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ClusterIssuerSpec `json:"spec,omitempty"`
+}
+
+type ClusterIssuerSpec struct {
+	// ACMEUserSecretName is the name of the Secret holding the ACME account,
+	// in the namespace where operator runs.
+	ACMEUserSecretName string `json:"acmeUserSecretName"`
+
+	// ChallengeProvider is used for Certificates that don't set spec.challengeProvider.
+	// Only dns provider is supported, its credentialSecretName is read from the namespace where operator runs.
+	// +optional
+	ChallengeProvider ChallengeProvider `json:"challengeProvider,omitempty"`
+
+	// AllowedNamespaces selects the namespaces whose Certificates may use this issuer, by their labels.
+	// Certificates of all namespaces may use it, if not set.
+	// +optional
+	AllowedNamespaces *metav1.LabelSelector `json:"allowedNamespaces,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ClusterIssuerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterIssuer `json:"items,omitempty"`
+}
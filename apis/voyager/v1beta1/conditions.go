@@ -0,0 +1,85 @@
+package v1beta1
+
+import (
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ingressReadyConditions are the conditions that must be true for an Ingress to be Ready.
+var ingressReadyConditions = []IngressConditionType{
+	IngressConfigRendered,
+	IngressConfigApplied,
+	IngressBackendsResolved,
+	IngressCertificatesReady,
+}
+
+// GetIngressCondition returns the condition of type t, or nil if not found.
+func GetIngressCondition(conditions []IngressCondition, t IngressConditionType) *IngressCondition {
+	for i := range conditions {
+		if conditions[i].Type == t {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetIngressCondition returns conditions with cond added or replaced. LastTransitionTime is set to now,
+// unless the status of the condition has not changed. Ready condition is updated to reflect the others.
+func SetIngressCondition(conditions []IngressCondition, cond IngressCondition, now metav1.Time) []IngressCondition {
+	result := setIngressCondition(conditions, cond, now)
+	if cond.Type != IngressReady {
+		result = setIngressCondition(result, ingressReadyCondition(result), now)
+	}
+	return result
+}
+
+func setIngressCondition(conditions []IngressCondition, cond IngressCondition, now metav1.Time) []IngressCondition {
+	result := make([]IngressCondition, 0, len(conditions)+1)
+	found := false
+	for _, c := range conditions {
+		if c.Type == cond.Type {
+			found = true
+			cond.LastTransitionTime = c.LastTransitionTime
+			if c.Status != cond.Status {
+				cond.LastTransitionTime = now
+			}
+			c = cond
+		}
+		result = append(result, c)
+	}
+	if !found {
+		cond.LastTransitionTime = now
+		result = append(result, cond)
+	}
+	return result
+}
+
+// ingressReadyCondition returns Ready condition for the given conditions. It is Unknown until all
+// ingressReadyConditions are reported, and False with the reason of the first one that is not true.
+func ingressReadyCondition(conditions []IngressCondition) IngressCondition {
+	for _, t := range ingressReadyConditions {
+		c := GetIngressCondition(conditions, t)
+		if c == nil {
+			return IngressCondition{
+				Type:    IngressReady,
+				Status:  core.ConditionUnknown,
+				Reason:  "Waiting",
+				Message: "Waiting for " + string(t) + " condition",
+			}
+		}
+		if c.Status != core.ConditionTrue {
+			return IngressCondition{
+				Type:    IngressReady,
+				Status:  c.Status,
+				Reason:  c.Reason,
+				Message: string(t) + ": " + c.Message,
+			}
+		}
+	}
+	return IngressCondition{
+		Type:    IngressReady,
+		Status:  core.ConditionTrue,
+		Reason:  "Ready",
+		Message: "HAProxy is serving the latest config",
+	}
+}
@@ -0,0 +1,47 @@
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetIngressCondition(t *testing.T) {
+	t1 := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	t2 := metav1.NewTime(time.Now().Truncate(time.Second))
+
+	conditions := SetIngressCondition(nil, IngressCondition{Type: IngressConfigRendered, Status: core.ConditionTrue}, t1)
+	ready := GetIngressCondition(conditions, IngressReady)
+	if assert.NotNil(t, ready) {
+		assert.Equal(t, core.ConditionUnknown, ready.Status)
+		assert.Contains(t, ready.Message, string(IngressConfigApplied))
+	}
+
+	for _, ct := range []IngressConditionType{IngressConfigApplied, IngressBackendsResolved, IngressCertificatesReady} {
+		conditions = SetIngressCondition(conditions, IngressCondition{Type: ct, Status: core.ConditionTrue}, t1)
+	}
+	assert.Len(t, conditions, 5)
+	assert.Equal(t, core.ConditionTrue, GetIngressCondition(conditions, IngressReady).Status)
+
+	conditions = SetIngressCondition(conditions, IngressCondition{
+		Type:    IngressBackendsResolved,
+		Status:  core.ConditionFalse,
+		Reason:  "BackendsSkipped",
+		Message: "spec.rules[0].http.paths[0] skipped",
+	}, t2)
+	resolved := GetIngressCondition(conditions, IngressBackendsResolved)
+	assert.Equal(t, t2, resolved.LastTransitionTime)
+	ready = GetIngressCondition(conditions, IngressReady)
+	assert.Equal(t, core.ConditionFalse, ready.Status)
+	assert.Equal(t, "BackendsSkipped", ready.Reason)
+	assert.Equal(t, t2, ready.LastTransitionTime)
+
+	// unchanged status keeps transition time
+	conditions = SetIngressCondition(conditions, IngressCondition{Type: IngressConfigRendered, Status: core.ConditionTrue, Message: "new"}, t2)
+	rendered := GetIngressCondition(conditions, IngressConfigRendered)
+	assert.Equal(t, t1, rendered.LastTransitionTime)
+	assert.Equal(t, "new", rendered.Message)
+}
@@ -2,6 +2,7 @@ package v1beta1
 
 import (
 	crdutils "github.com/appscode/kutil/apiextensions/v1beta1"
+	"github.com/appscode/voyager/apis/voyager"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 )
 
@@ -17,9 +18,10 @@ func (r Ingress) CustomResourceDefinition() *apiextensions.CustomResourceDefinit
 		Labels: crdutils.Labels{
 			LabelsMap: map[string]string{"app": "voyager"},
 		},
-		SpecDefinitionName:    "github.com/appscode/voyager/apis/voyager/v1beta1.Ingress",
-		EnableValidation:      true,
-		GetOpenAPIDefinitions: GetOpenAPIDefinitions,
+		SpecDefinitionName:      "github.com/appscode/voyager/apis/voyager/v1beta1.Ingress",
+		EnableValidation:        true,
+		GetOpenAPIDefinitions:   GetOpenAPIDefinitions,
+		EnableStatusSubresource: voyager.EnableStatusSubresource,
 	})
 }
 
@@ -40,3 +42,21 @@ func (c Certificate) CustomResourceDefinition() *apiextensions.CustomResourceDef
 		GetOpenAPIDefinitions: GetOpenAPIDefinitions,
 	})
 }
+
+func (c ClusterIssuer) CustomResourceDefinition() *apiextensions.CustomResourceDefinition {
+	return crdutils.NewCustomResourceDefinition(crdutils.Config{
+		Group:         SchemeGroupVersion.Group,
+		Version:       SchemeGroupVersion.Version,
+		Plural:        ResourcePluralClusterIssuer,
+		Singular:      ResourceSingularClusterIssuer,
+		Kind:          ResourceKindClusterIssuer,
+		ShortNames:    []string{"ciss"},
+		ResourceScope: string(apiextensions.ClusterScoped),
+		Labels: crdutils.Labels{
+			LabelsMap: map[string]string{"app": "voyager"},
+		},
+		SpecDefinitionName:    "github.com/appscode/voyager/apis/voyager/v1beta1.ClusterIssuer",
+		EnableValidation:      true,
+		GetOpenAPIDefinitions: GetOpenAPIDefinitions,
+	})
+}
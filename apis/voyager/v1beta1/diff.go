@@ -1,9 +1,12 @@
 package v1beta1
 
 import (
+	"crypto/ecdsa"
+	"crypto/rsa"
 	"crypto/x509"
 	"net"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -66,13 +69,33 @@ func (r Ingress) HasChanged(o Ingress) (bool, error) {
 	return !reflect.DeepEqual(ra, oa), nil
 }
 
+// FindTLSSecret returns the TLS certificate used for host h. Exact host matches take precedence
+// over wildcard hosts. A wildcard host "*.example.com" matches a single left-most label, ie,
+// "www.example.com", but not "example.com" or "a.b.example.com". For backward compatibility,
+// "*.example.com" also matches "example.com" when no other entry does.
 func (r Ingress) FindTLSSecret(h string) (*LocalTypedReference, bool) {
 	if h == "" {
 		return nil, false
 	}
 	for _, tls := range r.Spec.TLS {
 		for _, host := range tls.Hosts {
-			if host == h || (h != "" && host == "*."+h) {
+			if host == h {
+				return tls.Ref, true
+			}
+		}
+	}
+	if i := strings.Index(h, "."); i > 0 && h[:i] != "*" {
+		for _, tls := range r.Spec.TLS {
+			for _, host := range tls.Hosts {
+				if host == "*"+h[i:] {
+					return tls.Ref, true
+				}
+			}
+		}
+	}
+	for _, tls := range r.Spec.TLS {
+		for _, host := range tls.Hosts {
+			if host == "*."+h {
 				return tls.Ref, true
 			}
 		}
@@ -219,17 +242,113 @@ func (c Certificate) MatchesDomains(crt *x509.Certificate) bool {
 	return crtDomains.Equal(sets.NewString(c.Spec.Domains...))
 }
 
+// UsesACME returns true if this certificate is issued by an ACME server.
+func (c Certificate) UsesACME() bool {
+	return c.Spec.Issuer == nil || c.Spec.Issuer.ACME != nil
+}
+
+// ClusterIssuerName returns the name of the ClusterIssuer whose ACME account is used, if any.
+func (c Certificate) ClusterIssuerName() string {
+	if c.Spec.Issuer != nil && c.Spec.Issuer.ACME != nil {
+		return c.Spec.Issuer.ACME.ClusterIssuer
+	}
+	return ""
+}
+
+// CertificateDuration returns the requested lifetime of certificates signed by selfSigned and ca issuers.
+func (c Certificate) CertificateDuration() time.Duration {
+	if c.Spec.Duration != nil && c.Spec.Duration.Duration > 0 {
+		return c.Spec.Duration.Duration
+	}
+	return DefaultCertificateDuration
+}
+
+// KeyParameters returns the algorithm and size of the private key used to issue this certificate.
+func (c Certificate) KeyParameters() (KeyAlgorithm, int) {
+	alg, size := c.Spec.KeyAlgorithm, c.Spec.KeySize
+	if alg == "" {
+		alg = KeyAlgorithmRSA
+	}
+	if size == 0 {
+		switch alg {
+		case KeyAlgorithmRSA:
+			size = 2048
+		case KeyAlgorithmECDSA:
+			size = 256
+		}
+	}
+	return alg, size
+}
+
+// MatchesKey returns true if the public key of crt matches the key parameters of this certificate.
+func (c Certificate) MatchesKey(crt *x509.Certificate) bool {
+	alg, size := c.KeyParameters()
+	switch key := crt.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return alg == KeyAlgorithmRSA && key.N.BitLen() == size
+	case *ecdsa.PublicKey:
+		return alg == KeyAlgorithmECDSA && key.Curve.Params().BitSize == size
+	}
+	return false
+}
+
 func (c Certificate) ShouldRenew(crt *x509.Certificate) bool {
-	return !crt.NotAfter.After(time.Now().Add(time.Hour * 24 * 7))
+	return !time.Now().Before(c.RenewalTime(crt))
+}
+
+// RenewalTime returns the time when crt should be renewed according to spec.renewBefore.
+func (c Certificate) RenewalTime(crt *x509.Certificate) time.Time {
+	lifetime := crt.NotAfter.Sub(crt.NotBefore)
+	renewBefore, percent, err := c.parseRenewBefore()
+	if err != nil {
+		renewBefore = DefaultRenewBefore
+	} else if percent > 0 {
+		renewBefore = time.Duration(float64(lifetime) * percent / 100)
+	}
+	if renewBefore >= lifetime {
+		return crt.NotBefore.Add(lifetime * 2 / 3)
+	}
+	return crt.NotAfter.Add(-renewBefore)
+}
+
+// parseRenewBefore returns either the duration or the percentage of lifetime in spec.renewBefore.
+func (c Certificate) parseRenewBefore() (time.Duration, float64, error) {
+	if c.Spec.RenewBefore == "" {
+		return DefaultRenewBefore, 0, nil
+	}
+	if strings.HasSuffix(c.Spec.RenewBefore, "%") {
+		percent, err := strconv.ParseFloat(strings.TrimSuffix(c.Spec.RenewBefore, "%"), 64)
+		if err != nil {
+			return 0, 0, err
+		}
+		if percent <= 0 || percent >= 100 {
+			return 0, 0, errors.New("percentage must be between 0 and 100")
+		}
+		return 0, percent, nil
+	}
+	d, err := time.ParseDuration(c.Spec.RenewBefore)
+	if err != nil {
+		return 0, 0, err
+	}
+	if d <= 0 {
+		return 0, 0, errors.New("duration must be positive")
+	}
+	return d, 0, nil
 }
 
 func (c Certificate) IsRateLimited() bool {
+	return time.Now().Before(c.RateLimitedUntil())
+}
+
+// RateLimitedUntil returns when the rate limit reported by the ACME server is expected to be lifted.
+// It returns zero time, if the certificate is not rate limited.
+func (c Certificate) RateLimitedUntil() time.Time {
 	for _, cond := range c.Status.Conditions {
 		if cond.Type == CertificateRateLimited {
-			return time.Now().Add(-24 * time.Hour).Before(cond.LastUpdateTime.Time)
+			return cond.LastUpdateTime.Add(24 * time.Hour)
 		}
 	}
-	return false
+	return time.Time{}
 }
 
 func (c Certificate) SecretName() string {
@@ -1,7 +1,13 @@
 package v1beta1
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -147,3 +153,96 @@ func TestIsPortChanged(t *testing.T) {
 	assert.True(t, old.IsPortChanged(*new, ""))
 
 }
+
+func TestFindTLSSecret(t *testing.T) {
+	ing := Ingress{
+		Spec: IngressSpec{
+			TLS: []IngressTLS{
+				{
+					Ref:   &LocalTypedReference{Kind: "Secret", Name: "wildcard"},
+					Hosts: []string{"*.example.com"},
+				},
+				{
+					Ref:   &LocalTypedReference{Kind: "Certificate", Name: "exact"},
+					Hosts: []string{"www.example.com"},
+				},
+			},
+		},
+	}
+
+	dataTable := map[string]string{
+		"www.example.com":   "exact",
+		"app.example.com":   "wildcard",
+		"example.com":       "wildcard",
+		"a.b.example.com":   "",
+		"app.example.org":   "",
+		"*.example.com":     "wildcard",
+		"":                  "",
+		"app.example.com.x": "",
+	}
+	for host, expected := range dataTable {
+		ref, found := ing.FindTLSSecret(host)
+		if expected == "" {
+			assert.False(t, found, host)
+			continue
+		}
+		if assert.True(t, found, host) {
+			assert.Equal(t, expected, ref.Name, host)
+		}
+	}
+}
+
+func TestCertificateMatchesKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaCrt := &x509.Certificate{PublicKey: rsaKey.Public()}
+	ecdsaCrt := &x509.Certificate{PublicKey: ecdsaKey.Public()}
+
+	crd := Certificate{}
+	alg, size := crd.KeyParameters()
+	assert.Equal(t, KeyAlgorithmRSA, alg)
+	assert.Equal(t, 2048, size)
+	assert.True(t, crd.MatchesKey(rsaCrt))
+	assert.False(t, crd.MatchesKey(ecdsaCrt))
+
+	crd.Spec.KeySize = 4096
+	assert.False(t, crd.MatchesKey(rsaCrt))
+
+	crd.Spec = CertificateSpec{KeyAlgorithm: KeyAlgorithmECDSA}
+	alg, size = crd.KeyParameters()
+	assert.Equal(t, KeyAlgorithmECDSA, alg)
+	assert.Equal(t, 256, size)
+	assert.False(t, crd.MatchesKey(ecdsaCrt))
+
+	crd.Spec.KeySize = 384
+	assert.True(t, crd.MatchesKey(ecdsaCrt))
+	assert.False(t, crd.MatchesKey(rsaCrt))
+}
+
+func TestCertificateRenewalTime(t *testing.T) {
+	notBefore := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	crt := &x509.Certificate{NotBefore: notBefore, NotAfter: notBefore.Add(90 * 24 * time.Hour)}
+
+	dataTable := map[string]time.Time{
+		"":      crt.NotAfter.Add(-DefaultRenewBefore),
+		"720h":  crt.NotAfter.Add(-720 * time.Hour),
+		"50%":   notBefore.Add(45 * 24 * time.Hour),
+		"2160h": notBefore.Add(60 * 24 * time.Hour), // not shorter than lifetime
+	}
+	for renewBefore, expected := range dataTable {
+		crd := Certificate{Spec: CertificateSpec{RenewBefore: renewBefore}}
+		assert.Equal(t, expected, crd.RenewalTime(crt), renewBefore)
+	}
+
+	for _, renewBefore := range []string{"7d", "-1h", "0%", "100%", "abc%"} {
+		crd := Certificate{Spec: CertificateSpec{RenewBefore: renewBefore}}
+		_, _, err := crd.parseRenewBefore()
+		assert.Error(t, err, renewBefore)
+	}
+}
@@ -59,6 +59,12 @@ type IngressSpec struct {
 	// port according to the hostname specified through the SNI TLS extension.
 	TLS []IngressTLS `json:"tls,omitempty"`
 
+	// DefaultCertificate is the certificate served to clients that do not send SNI or
+	// send a host name that does not match any entry in TLS.
+	// If not set, the default certificate of the operator is used, if any.
+	// +optional
+	DefaultCertificate *LocalTypedReference `json:"defaultCertificate,omitempty"`
+
 	// Frontend rules specifies a set of rules that should be applied in
 	// HAProxy frontend configuration. The set of keywords are from here
 	// https://cbonte.github.io/haproxy-dconv/1.7/configuration.html#4.1
@@ -132,12 +138,140 @@ type IngressTLS struct {
 
 	// Ref to used tls termination.
 	Ref *LocalTypedReference `json:"ref,omitempty"`
+
+	// ALPN protocols advertised for the hosts of this certificate.
+	// Overrides the default alpn set on the frontend bind line.
+	// +optional
+	ALPN []string `json:"alpn,omitempty"`
+
+	// Ciphers is an OpenSSL cipher list used for the hosts of this certificate.
+	// +optional
+	Ciphers string `json:"ciphers,omitempty"`
+
+	// Auth configures client certificate verification for the hosts of this certificate.
+	// Only secretName and verifyClient are honored here.
+	// +optional
+	Auth *TLSAuth `json:"auth,omitempty"`
 }
 
 // IngressStatus describe the current state of the Ingress.
 type IngressStatus struct {
 	// LoadBalancer contains the current status of the load-balancer.
 	LoadBalancer core.LoadBalancerStatus `json:"loadBalancer,omitempty"`
+
+	// ObservedGeneration is the generation of the Ingress last processed by the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ConfigHash is the md5 hash of haproxy.cfg last rendered by the operator for this Ingress.
+	// +optional
+	ConfigHash string `json:"configHash,omitempty"`
+
+	// TLS is the state of the certificates in TLS Secrets referred by spec.tls. Certificates
+	// issued via Certificate crds are tracked in the status of the Certificate instead.
+	// +optional
+	TLS []IngressTLSStatus `json:"tls,omitempty"`
+
+	// Backends is the result of resolving the backend of each rule and path in haproxy.cfg
+	// last rendered by the operator. Backends without endpoints are left out of haproxy.cfg.
+	// +optional
+	Backends []IngressBackendStatus `json:"backends,omitempty"`
+
+	// Pods is the haproxy.cfg each HAProxy pod is running with, as reported by the pod itself.
+	// ConfigApplied condition is aggregated from these.
+	// +optional
+	Pods []IngressPodStatus `json:"pods,omitempty"`
+
+	// Conditions are the latest observations of the state of the Ingress.
+	// +optional
+	Conditions []IngressCondition `json:"conditions,omitempty"`
+}
+
+type IngressConditionType string
+
+const (
+	// IngressReady is true when ConfigRendered, ConfigApplied, BackendsResolved and CertificatesReady are true.
+	IngressReady IngressConditionType = "Ready"
+	// IngressConfigRendered is true if haproxy.cfg is rendered by the operator for the latest spec.
+	IngressConfigRendered IngressConditionType = "ConfigRendered"
+	// IngressConfigApplied is true if all HAProxy pods are running with the rendered haproxy.cfg and certificates.
+	IngressConfigApplied IngressConditionType = "ConfigApplied"
+	// IngressBackendsResolved is true if all backends have endpoints. Otherwise, some paths are left out of haproxy.cfg.
+	IngressBackendsResolved IngressConditionType = "BackendsResolved"
+	// IngressCertificatesReady is true if all TLS Secrets are valid and all Certificates are issued.
+	IngressCertificatesReady IngressConditionType = "CertificatesReady"
+	// IngressCertificatePending is true while a Certificate referred by spec.tls is not issued yet.
+	// Its hosts are served with a self-signed placeholder certificate meanwhile.
+	IngressCertificatePending IngressConditionType = "CertificatePending"
+)
+
+// IngressCondition describes the state of an Ingress at a certain point.
+type IngressCondition struct {
+	// Type of the condition.
+	Type IngressConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status core.ConditionStatus `json:"status"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a brief CamelCase reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable message with details about the transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// IngressTLSStatus is the state of the certificate in a TLS Secret.
+type IngressTLSStatus struct {
+	// SecretName is the name of the TLS Secret.
+	SecretName string `json:"secretName"`
+	// NotAfter is the expiry time of the certificate.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+	// Valid is true if the certificate is not expired, matches its private key
+	// and covers the hosts it is used for.
+	Valid bool `json:"valid"`
+	// Message describes why the certificate is invalid, or that it expires soon.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// IngressPodStatus is the state of haproxy.cfg and certificates in an HAProxy pod.
+type IngressPodStatus struct {
+	// Name of the pod.
+	Name string `json:"name"`
+	// ConfigHash is the md5 hash of haproxy.cfg the pod is running with.
+	// +optional
+	ConfigHash string `json:"configHash,omitempty"`
+	// Message describes why mounting config or certificates or reloading HAProxy failed in the pod.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// IngressBackendStatus is the result of resolving the backend of an Ingress rule or path.
+type IngressBackendStatus struct {
+	// Field is the path of the backend in the Ingress, e.g. spec.rules[0].http.paths[1], spec.rules[1].tcp or spec.backend.
+	Field string `json:"field"`
+	// Host of the rule.
+	// +optional
+	Host string `json:"host,omitempty"`
+	// Path of the http rule.
+	// +optional
+	Path string `json:"path,omitempty"`
+	// ServiceName is the name of the referenced service.
+	ServiceName string `json:"serviceName"`
+	// ServicePort is the port of the referenced service.
+	ServicePort intstr.IntOrString `json:"servicePort"`
+	// Backend is the name of the backend in haproxy.cfg. Empty if the backend is skipped.
+	// +optional
+	Backend string `json:"backend,omitempty"`
+	// Endpoints is the number of endpoints of the backend. Changes in the number alone are not
+	// reported, unless the backend gains its first or loses its last endpoint.
+	Endpoints int32 `json:"endpoints"`
+	// Reason describes why the backend is left out of haproxy.cfg.
+	// +optional
+	Reason string `json:"reason,omitempty"`
 }
 
 // IngressRule represents the rules mapping the paths under a specified host to
@@ -35,3 +35,13 @@ func (c Certificate) ObjectReference() *core.ObjectReference {
 		ResourceVersion: c.ResourceVersion,
 	}
 }
+
+func (c ClusterIssuer) ObjectReference() *core.ObjectReference {
+	return &core.ObjectReference{
+		APIVersion:      SchemeGroupVersion.String(),
+		Kind:            ResourceKindClusterIssuer,
+		Name:            c.Name,
+		UID:             c.UID,
+		ResourceVersion: c.ResourceVersion,
+	}
+}
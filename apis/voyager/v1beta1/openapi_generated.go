@@ -66,6 +66,36 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 			},
 			Dependencies: []string{},
 		},
+		"github.com/appscode/voyager/apis/voyager/v1beta1.ACMEIssuer": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"preferredChain": {
+							SchemaProps: spec.SchemaProps{
+								Description: "PreferredChain selects the certificate chain whose top-most certificate is issued by this common name, ie, \"ISRG Root X1\", if the ACME server offers alternate chains. Otherwise the default chain is used.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"skipSelfCheck": {
+							SchemaProps: spec.SchemaProps{
+								Description: "SkipSelfCheck submits challenges to the ACME server without checking first that they are reachable from operator. Use it if operator can't reach the Ingress via its public address.",
+								Type:        []string{"boolean"},
+								Format:      "",
+							},
+						},
+						"clusterIssuer": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ClusterIssuer is the name of a ClusterIssuer whose ACME account is used. Its challenge provider is used, unless spec.challengeProvider is set. spec.acmeUserSecretName is ignored.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+					},
+				},
+			},
+			Dependencies: []string{},
+		},
 		"github.com/appscode/voyager/apis/voyager/v1beta1.AuthOption": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
@@ -119,6 +149,23 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 			},
 			Dependencies: []string{},
 		},
+		"github.com/appscode/voyager/apis/voyager/v1beta1.CAIssuer": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"secretName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "SecretName is the name of the Secret holding the CA certificate and private key in tls.crt and tls.key. The Secret must be in the namespace of the Certificate.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+					},
+					Required: []string{"secretName"},
+				},
+			},
+			Dependencies: []string{},
+		},
 		"github.com/appscode/voyager/apis/voyager/v1beta1.Certificate": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
@@ -234,6 +281,39 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Format: "",
 							},
 						},
+						"keyAlgorithm": {
+							SchemaProps: spec.SchemaProps{
+								Type:   []string{"string"},
+								Format: "",
+							},
+						},
+						"keySize": {
+							SchemaProps: spec.SchemaProps{
+								Type:   []string{"integer"},
+								Format: "int32",
+							},
+						},
+						"chainIssuer": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ChainIssuer is the common name of the issuer of the top-most certificate in the chain.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"acmeServerURL": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ACMEServerURL is the directory url of the ACME server that issued the certificate.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"externalAccountKeyID": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ExternalAccountKeyID is the key id of the external account bound to the ACME account.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
 					},
 					Required: []string{"certURL", "certStableURL"},
 				},
@@ -241,6 +321,41 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 			Dependencies: []string{
 				"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 		},
+		"github.com/appscode/voyager/apis/voyager/v1beta1.CertificateIssuer": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "CertificateIssuer selects the issuer of a certificate. Only one of its fields must be set.",
+					Properties: map[string]spec.Schema{
+						"acme": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ACME issues the certificate from an ACME server like Let's Encrypt, using spec.challengeProvider and spec.acmeUserSecretName.",
+								Ref:         ref("github.com/appscode/voyager/apis/voyager/v1beta1.ACMEIssuer"),
+							},
+						},
+						"selfSigned": {
+							SchemaProps: spec.SchemaProps{
+								Description: "SelfSigned signs the certificate with its own private key.",
+								Ref:         ref("github.com/appscode/voyager/apis/voyager/v1beta1.SelfSignedIssuer"),
+							},
+						},
+						"ca": {
+							SchemaProps: spec.SchemaProps{
+								Description: "CA signs the certificate with a CA certificate and private key.",
+								Ref:         ref("github.com/appscode/voyager/apis/voyager/v1beta1.CAIssuer"),
+							},
+						},
+						"vault": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Vault issues the certificate from a Vault PKI secrets engine.",
+								Ref:         ref("github.com/appscode/voyager/apis/voyager/v1beta1.VaultIssuer"),
+							},
+						},
+					},
+				},
+			},
+			Dependencies: []string{
+				"github.com/appscode/voyager/apis/voyager/v1beta1.ACMEIssuer", "github.com/appscode/voyager/apis/voyager/v1beta1.CAIssuer", "github.com/appscode/voyager/apis/voyager/v1beta1.SelfSignedIssuer", "github.com/appscode/voyager/apis/voyager/v1beta1.VaultIssuer"},
+		},
 		"github.com/appscode/voyager/apis/voyager/v1beta1.CertificateList": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
@@ -282,6 +397,56 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 			Dependencies: []string{
 				"github.com/appscode/voyager/apis/voyager/v1beta1.Certificate", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 		},
+		"github.com/appscode/voyager/apis/voyager/v1beta1.CertificateRevocation": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"reason": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Reason is sent to the ACME server with the revocation request. One of Unspecified (default), KeyCompromise, AffiliationChanged, Superseded and CessationOfOperation.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"reissue": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Reissue issues a new certificate with a fresh private key right after revocation.",
+								Type:        []string{"boolean"},
+								Format:      "",
+							},
+						},
+					},
+				},
+			},
+			Dependencies: []string{},
+		},
+		"github.com/appscode/voyager/apis/voyager/v1beta1.CertificateRevocationStatus": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"serialNumber": {
+							SchemaProps: spec.SchemaProps{
+								Type:   []string{"string"},
+								Format: "",
+							},
+						},
+						"reason": {
+							SchemaProps: spec.SchemaProps{
+								Type:   []string{"string"},
+								Format: "",
+							},
+						},
+						"revocationTime": {
+							SchemaProps: spec.SchemaProps{
+								Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+							},
+						},
+					},
+				},
+			},
+			Dependencies: []string{
+				"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+		},
 		"github.com/appscode/voyager/apis/voyager/v1beta1.CertificateSpec": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
@@ -300,9 +465,21 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								},
 							},
 						},
+						"issuer": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Issuer signs the certificate. Default is acme.",
+								Ref:         ref("github.com/appscode/voyager/apis/voyager/v1beta1.CertificateIssuer"),
+							},
+						},
+						"duration": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Duration is the requested lifetime of certificates signed by selfSigned, ca and vault issuers. Default is 2160h (90 days), or the ttl of the PKI role for vault issuer. ACME servers choose the lifetime of their certificates.",
+								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+							},
+						},
 						"challengeProvider": {
 							SchemaProps: spec.SchemaProps{
-								Description: "ChallengeProvider details to verify domains",
+								Description: "ChallengeProvider details to verify domains. Required for acme issuer.",
 								Ref:         ref("github.com/appscode/voyager/apis/voyager/v1beta1.ChallengeProvider"),
 							},
 						},
@@ -319,6 +496,54 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Ref:         ref("github.com/appscode/voyager/apis/voyager/v1beta1.CertificateStorage"),
 							},
 						},
+						"dualKey": {
+							SchemaProps: spec.SchemaProps{
+								Description: "DualKey issues an ECDSA (P-256) certificate for the same domains along with the RSA certificate. The ECDSA certificate is stored under tls-ecdsa.crt and tls-ecdsa.key keys.",
+								Type:        []string{"boolean"},
+								Format:      "",
+							},
+						},
+						"keyAlgorithm": {
+							SchemaProps: spec.SchemaProps{
+								Description: "KeyAlgorithm is the algorithm of the certificate private key, rsa or ecdsa. Default is rsa. For dual key Certificates, it applies to the primary certificate and must be rsa.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"keySize": {
+							SchemaProps: spec.SchemaProps{
+								Description: "KeySize is the size of the certificate private key in bits. Supported sizes are 2048 (default) and 4096 for rsa, 256 (default) and 384 for ecdsa.",
+								Type:        []string{"integer"},
+								Format:      "int32",
+							},
+						},
+						"reuseKey": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ReuseKey keeps the existing private key when the certificate is renewed. By default, a new private key is generated on each renewal.",
+								Type:        []string{"boolean"},
+								Format:      "",
+							},
+						},
+						"renewBefore": {
+							SchemaProps: spec.SchemaProps{
+								Description: "RenewBefore is how long before expiry the certificate is renewed. It is either a duration, like 720h, or a percentage of the certificate lifetime, like 33%. Default is 168h (7 days). If the duration is not shorter than the certificate lifetime, the certificate is renewed after two thirds of its lifetime.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"revoke": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Revoke revokes the current certificate at the ACME server. A revoked certificate is not renewed, unless revoke.reissue is set. Remove it to issue a new certificate.",
+								Ref:         ref("github.com/appscode/voyager/apis/voyager/v1beta1.CertificateRevocation"),
+							},
+						},
+						"deletionPolicy": {
+							SchemaProps: spec.SchemaProps{
+								Description: "DeletionPolicy is one of Retain (default), Delete and RevokeAndDelete. With Delete and RevokeAndDelete, the stored certificate and the ACME user secret, if no longer used, are removed when the Certificate is deleted.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
 						"provider": {
 							SchemaProps: spec.SchemaProps{
 								Description: "Following fields are deprecated and will removed in future version. https://github.com/appscode/voyager/pull/506 Deprecated. DNS Provider.",
@@ -354,11 +579,10 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 							},
 						},
 					},
-					Required: []string{"challengeProvider", "acmeUserSecretName"},
 				},
 			},
 			Dependencies: []string{
-				"github.com/appscode/voyager/apis/voyager/v1beta1.CertificateStorage", "github.com/appscode/voyager/apis/voyager/v1beta1.ChallengeProvider", "github.com/appscode/voyager/apis/voyager/v1beta1.LocalTypedReference"},
+				"github.com/appscode/voyager/apis/voyager/v1beta1.CertificateIssuer", "github.com/appscode/voyager/apis/voyager/v1beta1.CertificateRevocation", "github.com/appscode/voyager/apis/voyager/v1beta1.CertificateStorage", "github.com/appscode/voyager/apis/voyager/v1beta1.ChallengeProvider", "github.com/appscode/voyager/apis/voyager/v1beta1.LocalTypedReference", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 		},
 		"github.com/appscode/voyager/apis/voyager/v1beta1.CertificateStatus": {
 			Schema: spec.Schema{
@@ -386,6 +610,31 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Ref: ref("github.com/appscode/voyager/apis/voyager/v1beta1.CertificateDetails"),
 							},
 						},
+						"nextRenewalTime": {
+							SchemaProps: spec.SchemaProps{
+								Description: "NextRenewalTime is when the last issued certificate is scheduled to be renewed.",
+								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+							},
+						},
+						"failureCount": {
+							SchemaProps: spec.SchemaProps{
+								Description: "FailureCount is the number of consecutive failed attempts to issue or renew the certificate.",
+								Type:        []string{"integer"},
+								Format:      "int32",
+							},
+						},
+						"nextAttemptTime": {
+							SchemaProps: spec.SchemaProps{
+								Description: "NextAttemptTime is when operator retries to issue or renew the certificate after a failure.",
+								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+							},
+						},
+						"revocation": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Revocation describes the certificate revoked for spec.revoke. It is cleared when spec.revoke is removed.",
+								Ref:         ref("github.com/appscode/voyager/apis/voyager/v1beta1.CertificateRevocationStatus"),
+							},
+						},
 						"certificateObtained": {
 							SchemaProps: spec.SchemaProps{
 								Description: "Deprecated",
@@ -417,7 +666,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 				},
 			},
 			Dependencies: []string{
-				"github.com/appscode/voyager/apis/voyager/v1beta1.ACMECertificateDetails", "github.com/appscode/voyager/apis/voyager/v1beta1.CertificateCondition", "github.com/appscode/voyager/apis/voyager/v1beta1.CertificateDetails", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+				"github.com/appscode/voyager/apis/voyager/v1beta1.ACMECertificateDetails", "github.com/appscode/voyager/apis/voyager/v1beta1.CertificateCondition", "github.com/appscode/voyager/apis/voyager/v1beta1.CertificateDetails", "github.com/appscode/voyager/apis/voyager/v1beta1.CertificateRevocationStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 		},
 		"github.com/appscode/voyager/apis/voyager/v1beta1.CertificateStorage": {
 			Schema: spec.Schema{
@@ -459,6 +708,111 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 			Dependencies: []string{
 				"github.com/appscode/voyager/apis/voyager/v1beta1.DNSChallengeProvider", "github.com/appscode/voyager/apis/voyager/v1beta1.HTTPChallengeProvider"},
 		},
+		"github.com/appscode/voyager/apis/voyager/v1beta1.ClusterIssuer": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"kind": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"apiVersion": {
+							SchemaProps: spec.SchemaProps{
+								Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"metadata": {
+							SchemaProps: spec.SchemaProps{
+								Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+							},
+						},
+						"spec": {
+							SchemaProps: spec.SchemaProps{
+								Ref: ref("github.com/appscode/voyager/apis/voyager/v1beta1.ClusterIssuerSpec"),
+							},
+						},
+					},
+				},
+			},
+			Dependencies: []string{
+				"github.com/appscode/voyager/apis/voyager/v1beta1.ClusterIssuerSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+		},
+		"github.com/appscode/voyager/apis/voyager/v1beta1.ClusterIssuerList": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"kind": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"apiVersion": {
+							SchemaProps: spec.SchemaProps{
+								Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"metadata": {
+							SchemaProps: spec.SchemaProps{
+								Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+							},
+						},
+						"items": {
+							SchemaProps: spec.SchemaProps{
+								Type: []string{"array"},
+								Items: &spec.SchemaOrArray{
+									Schema: &spec.Schema{
+										SchemaProps: spec.SchemaProps{
+											Ref: ref("github.com/appscode/voyager/apis/voyager/v1beta1.ClusterIssuer"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Dependencies: []string{
+				"github.com/appscode/voyager/apis/voyager/v1beta1.ClusterIssuer", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+		},
+		"github.com/appscode/voyager/apis/voyager/v1beta1.ClusterIssuerSpec": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"acmeUserSecretName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ACMEUserSecretName is the name of the Secret holding the ACME account, in the namespace where operator runs.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"challengeProvider": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ChallengeProvider is used for Certificates that don't set spec.challengeProvider. Only dns provider is supported, its credentialSecretName is read from the namespace where operator runs.",
+								Ref:         ref("github.com/appscode/voyager/apis/voyager/v1beta1.ChallengeProvider"),
+							},
+						},
+						"allowedNamespaces": {
+							SchemaProps: spec.SchemaProps{
+								Description: "AllowedNamespaces selects the namespaces whose Certificates may use this issuer, by their labels. Certificates of all namespaces may use it, if not set.",
+								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+							},
+						},
+					},
+					Required: []string{"acmeUserSecretName"},
+				},
+			},
+			Dependencies: []string{
+				"github.com/appscode/voyager/apis/voyager/v1beta1.ChallengeProvider", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+		},
 		"github.com/appscode/voyager/apis/voyager/v1beta1.DNSChallengeProvider": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
@@ -476,10 +830,17 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Format: "",
 							},
 						},
+						"webhook": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Webhook is the endpoint called to present and clean up TXT records. Required for webhook provider.",
+								Ref:         ref("github.com/appscode/voyager/apis/voyager/v1beta1.DNSWebhook"),
+							},
+						},
 					},
 				},
 			},
-			Dependencies: []string{},
+			Dependencies: []string{
+				"github.com/appscode/voyager/apis/voyager/v1beta1.DNSWebhook"},
 		},
 		"github.com/appscode/voyager/apis/voyager/v1beta1.DNSResolver": {
 			Schema: spec.Schema{
@@ -548,6 +909,67 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 			},
 			Dependencies: []string{},
 		},
+		"github.com/appscode/voyager/apis/voyager/v1beta1.DNSWebhook": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "DNSWebhook is the endpoint of a webhook dns provider. Exactly one of url and service must be set.",
+					Properties: map[string]spec.Schema{
+						"url": {
+							SchemaProps: spec.SchemaProps{
+								Description: "URL of the webhook, ie, https://dns.example.com/acme",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"service": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Service refers to a Service in the namespace of the Certificate that serves the webhook.",
+								Ref:         ref("github.com/appscode/voyager/apis/voyager/v1beta1.DNSWebhookService"),
+							},
+						},
+					},
+				},
+			},
+			Dependencies: []string{
+				"github.com/appscode/voyager/apis/voyager/v1beta1.DNSWebhookService"},
+		},
+		"github.com/appscode/voyager/apis/voyager/v1beta1.DNSWebhookService": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"name": {
+							SchemaProps: spec.SchemaProps{
+								Type:   []string{"string"},
+								Format: "",
+							},
+						},
+						"port": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Port of the Service. Default is 443.",
+								Type:        []string{"integer"},
+								Format:      "int32",
+							},
+						},
+						"path": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Path of the webhook in the Service, ie, /acme",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"scheme": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Scheme is http or https. Default is https.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+					},
+					Required: []string{"name"},
+				},
+			},
+			Dependencies: []string{},
+		},
 		"github.com/appscode/voyager/apis/voyager/v1beta1.FrontendRule": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
@@ -828,38 +1250,151 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								},
 							},
 						},
-						"serviceName": {
+						"serviceName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Specifies the name of the referenced service.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"servicePort": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Specifies the port of the referenced service.",
+								Ref:         ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
+							},
+						},
+						"backendRules": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Serialized HAProxy rules to apply on server backend including request, response or header rewrite. acls also can be used. https://cbonte.github.io/haproxy-dconv/1.7/configuration.html#1",
+								Type:        []string{"array"},
+								Items: &spec.SchemaOrArray{
+									Schema: &spec.Schema{
+										SchemaProps: spec.SchemaProps{
+											Type:   []string{"string"},
+											Format: "",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Dependencies: []string{
+				"k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
+		},
+		"github.com/appscode/voyager/apis/voyager/v1beta1.IngressBackendStatus": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "IngressBackendStatus is the result of resolving the backend of an Ingress rule or path.",
+					Properties: map[string]spec.Schema{
+						"field": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Field is the path of the backend in the Ingress, e.g. spec.rules[0].http.paths[1], spec.rules[1].tcp or spec.backend.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"host": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Host of the rule.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"path": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Path of the http rule.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"serviceName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ServiceName is the name of the referenced service.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"servicePort": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ServicePort is the port of the referenced service.",
+								Ref:         ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
+							},
+						},
+						"backend": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Backend is the name of the backend in haproxy.cfg. Empty if the backend is skipped.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"endpoints": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Endpoints is the number of endpoints of the backend. Changes in the number alone are not reported, unless the backend gains its first or loses its last endpoint.",
+								Type:        []string{"integer"},
+								Format:      "int32",
+							},
+						},
+						"reason": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Reason describes why the backend is left out of haproxy.cfg.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+					},
+					Required: []string{"field", "serviceName", "servicePort", "endpoints"},
+				},
+			},
+			Dependencies: []string{
+				"k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
+		},
+		"github.com/appscode/voyager/apis/voyager/v1beta1.IngressCondition": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "IngressCondition describes the state of an Ingress at a certain point.",
+					Properties: map[string]spec.Schema{
+						"type": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Type of the condition.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"status": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Status of the condition, one of True, False, Unknown.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"lastTransitionTime": {
+							SchemaProps: spec.SchemaProps{
+								Description: "LastTransitionTime is the last time the condition transitioned from one status to another.",
+								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+							},
+						},
+						"reason": {
 							SchemaProps: spec.SchemaProps{
-								Description: "Specifies the name of the referenced service.",
+								Description: "Reason is a brief CamelCase reason for the condition's last transition.",
 								Type:        []string{"string"},
 								Format:      "",
 							},
 						},
-						"servicePort": {
-							SchemaProps: spec.SchemaProps{
-								Description: "Specifies the port of the referenced service.",
-								Ref:         ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
-							},
-						},
-						"backendRules": {
+						"message": {
 							SchemaProps: spec.SchemaProps{
-								Description: "Serialized HAProxy rules to apply on server backend including request, response or header rewrite. acls also can be used. https://cbonte.github.io/haproxy-dconv/1.7/configuration.html#1",
-								Type:        []string{"array"},
-								Items: &spec.SchemaOrArray{
-									Schema: &spec.Schema{
-										SchemaProps: spec.SchemaProps{
-											Type:   []string{"string"},
-											Format: "",
-										},
-									},
-								},
+								Description: "Message is a human readable message with details about the transition.",
+								Type:        []string{"string"},
+								Format:      "",
 							},
 						},
 					},
+					Required: []string{"type", "status"},
 				},
 			},
 			Dependencies: []string{
-				"k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
+				"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 		},
 		"github.com/appscode/voyager/apis/voyager/v1beta1.IngressList": {
 			Schema: spec.Schema{
@@ -906,6 +1441,38 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 			Dependencies: []string{
 				"github.com/appscode/voyager/apis/voyager/v1beta1.Ingress", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 		},
+		"github.com/appscode/voyager/apis/voyager/v1beta1.IngressPodStatus": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "IngressPodStatus is the state of haproxy.cfg and certificates in an HAProxy pod.",
+					Properties: map[string]spec.Schema{
+						"name": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Name of the pod.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"configHash": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ConfigHash is the md5 hash of haproxy.cfg the pod is running with.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"message": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Message describes why mounting config or certificates or reloading HAProxy failed in the pod.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+					},
+					Required: []string{"name"},
+				},
+			},
+			Dependencies: []string{},
+		},
 		"github.com/appscode/voyager/apis/voyager/v1beta1.IngressRef": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
@@ -1007,6 +1574,12 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								},
 							},
 						},
+						"defaultCertificate": {
+							SchemaProps: spec.SchemaProps{
+								Description: "DefaultCertificate is the certificate served to clients that do not send SNI or send a host name that does not match any entry in TLS. If not set, the default certificate of the operator is used, if any.",
+								Ref:         ref("github.com/appscode/voyager/apis/voyager/v1beta1.LocalTypedReference"),
+							},
+						},
 						"frontendRules": {
 							SchemaProps: spec.SchemaProps{
 								Description: "Frontend rules specifies a set of rules that should be applied in HAProxy frontend configuration. The set of keywords are from here https://cbonte.github.io/haproxy-dconv/1.7/configuration.html#4.1 Only frontend sections can be applied here. It is up to user to provide valid set of rules. This allows acls or other options in frontend sections in HAProxy config. Frontend rules will be mapped with Ingress Rules according to port.",
@@ -1124,7 +1697,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 				},
 			},
 			Dependencies: []string{
-				"github.com/appscode/voyager/apis/voyager/v1beta1.FrontendRule", "github.com/appscode/voyager/apis/voyager/v1beta1.HTTPIngressBackend", "github.com/appscode/voyager/apis/voyager/v1beta1.IngressRule", "github.com/appscode/voyager/apis/voyager/v1beta1.IngressTLS", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration"},
+				"github.com/appscode/voyager/apis/voyager/v1beta1.FrontendRule", "github.com/appscode/voyager/apis/voyager/v1beta1.HTTPIngressBackend", "github.com/appscode/voyager/apis/voyager/v1beta1.IngressRule", "github.com/appscode/voyager/apis/voyager/v1beta1.IngressTLS", "github.com/appscode/voyager/apis/voyager/v1beta1.LocalTypedReference", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration"},
 		},
 		"github.com/appscode/voyager/apis/voyager/v1beta1.IngressStatus": {
 			Schema: spec.Schema{
@@ -1137,11 +1710,77 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Ref:         ref("k8s.io/api/core/v1.LoadBalancerStatus"),
 							},
 						},
+						"observedGeneration": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ObservedGeneration is the generation of the Ingress last processed by the operator.",
+								Type:        []string{"integer"},
+								Format:      "int64",
+							},
+						},
+						"configHash": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ConfigHash is the md5 hash of haproxy.cfg last rendered by the operator for this Ingress.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"tls": {
+							SchemaProps: spec.SchemaProps{
+								Description: "TLS is the state of the certificates in TLS Secrets referred by spec.tls. Certificates issued via Certificate crds are tracked in the status of the Certificate instead.",
+								Type:        []string{"array"},
+								Items: &spec.SchemaOrArray{
+									Schema: &spec.Schema{
+										SchemaProps: spec.SchemaProps{
+											Ref: ref("github.com/appscode/voyager/apis/voyager/v1beta1.IngressTLSStatus"),
+										},
+									},
+								},
+							},
+						},
+						"backends": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Backends is the result of resolving the backend of each rule and path in haproxy.cfg last rendered by the operator. Backends without endpoints are left out of haproxy.cfg.",
+								Type:        []string{"array"},
+								Items: &spec.SchemaOrArray{
+									Schema: &spec.Schema{
+										SchemaProps: spec.SchemaProps{
+											Ref: ref("github.com/appscode/voyager/apis/voyager/v1beta1.IngressBackendStatus"),
+										},
+									},
+								},
+							},
+						},
+						"pods": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Pods is the haproxy.cfg each HAProxy pod is running with, as reported by the pod itself. ConfigApplied condition is aggregated from these.",
+								Type:        []string{"array"},
+								Items: &spec.SchemaOrArray{
+									Schema: &spec.Schema{
+										SchemaProps: spec.SchemaProps{
+											Ref: ref("github.com/appscode/voyager/apis/voyager/v1beta1.IngressPodStatus"),
+										},
+									},
+								},
+							},
+						},
+						"conditions": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Conditions are the latest observations of the state of the Ingress.",
+								Type:        []string{"array"},
+								Items: &spec.SchemaOrArray{
+									Schema: &spec.Schema{
+										SchemaProps: spec.SchemaProps{
+											Ref: ref("github.com/appscode/voyager/apis/voyager/v1beta1.IngressCondition"),
+										},
+									},
+								},
+							},
+						},
 					},
 				},
 			},
 			Dependencies: []string{
-				"k8s.io/api/core/v1.LoadBalancerStatus"},
+				"github.com/appscode/voyager/apis/voyager/v1beta1.IngressBackendStatus", "github.com/appscode/voyager/apis/voyager/v1beta1.IngressCondition", "github.com/appscode/voyager/apis/voyager/v1beta1.IngressPodStatus", "github.com/appscode/voyager/apis/voyager/v1beta1.IngressTLSStatus", "k8s.io/api/core/v1.LoadBalancerStatus"},
 		},
 		"github.com/appscode/voyager/apis/voyager/v1beta1.IngressTLS": {
 			Schema: spec.Schema{
@@ -1175,11 +1814,77 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Ref:         ref("github.com/appscode/voyager/apis/voyager/v1beta1.LocalTypedReference"),
 							},
 						},
+						"alpn": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ALPN protocols advertised for the hosts of this certificate. Overrides the default alpn set on the frontend bind line.",
+								Type:        []string{"array"},
+								Items: &spec.SchemaOrArray{
+									Schema: &spec.Schema{
+										SchemaProps: spec.SchemaProps{
+											Type:   []string{"string"},
+											Format: "",
+										},
+									},
+								},
+							},
+						},
+						"ciphers": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Ciphers is an OpenSSL cipher list used for the hosts of this certificate.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"auth": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Auth configures client certificate verification for the hosts of this certificate. Only secretName and verifyClient are honored here.",
+								Ref:         ref("github.com/appscode/voyager/apis/voyager/v1beta1.TLSAuth"),
+							},
+						},
 					},
 				},
 			},
 			Dependencies: []string{
-				"github.com/appscode/voyager/apis/voyager/v1beta1.LocalTypedReference"},
+				"github.com/appscode/voyager/apis/voyager/v1beta1.LocalTypedReference", "github.com/appscode/voyager/apis/voyager/v1beta1.TLSAuth"},
+		},
+		"github.com/appscode/voyager/apis/voyager/v1beta1.IngressTLSStatus": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "IngressTLSStatus is the state of the certificate in a TLS Secret.",
+					Properties: map[string]spec.Schema{
+						"secretName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "SecretName is the name of the TLS Secret.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"notAfter": {
+							SchemaProps: spec.SchemaProps{
+								Description: "NotAfter is the expiry time of the certificate.",
+								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+							},
+						},
+						"valid": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Valid is true if the certificate is not expired, matches its private key and covers the hosts it is used for.",
+								Type:        []string{"boolean"},
+								Format:      "",
+							},
+						},
+						"message": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Message describes why the certificate is invalid, or that it expires soon.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+					},
+					Required: []string{"secretName", "valid"},
+				},
+			},
+			Dependencies: []string{
+				"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 		},
 		"github.com/appscode/voyager/apis/voyager/v1beta1.LocalTypedReference": {
 			Schema: spec.Schema{
@@ -1258,6 +1963,14 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 			},
 			Dependencies: []string{},
 		},
+		"github.com/appscode/voyager/apis/voyager/v1beta1.SelfSignedIssuer": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{},
+				},
+			},
+			Dependencies: []string{},
+		},
 		"github.com/appscode/voyager/apis/voyager/v1beta1.TCPIngressRuleValue": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
@@ -1376,6 +2089,100 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 			},
 			Dependencies: []string{},
 		},
+		"github.com/appscode/voyager/apis/voyager/v1beta1.VaultAppRoleAuth": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"secretName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "SecretName is the name of the Secret holding role_id and secret_id keys.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"mountPath": {
+							SchemaProps: spec.SchemaProps{
+								Description: "MountPath of the approle auth method. Default is approle.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+					},
+					Required: []string{"secretName"},
+				},
+			},
+			Dependencies: []string{},
+		},
+		"github.com/appscode/voyager/apis/voyager/v1beta1.VaultAuth": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"kubernetes": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Kubernetes logs in with the service account token of operator and haproxy pods.",
+								Ref:         ref("github.com/appscode/voyager/apis/voyager/v1beta1.VaultKubernetesAuth"),
+							},
+						},
+						"appRole": {
+							SchemaProps: spec.SchemaProps{
+								Description: "AppRole logs in with role_id and secret_id keys of a Secret in the namespace of the Certificate.",
+								Ref:         ref("github.com/appscode/voyager/apis/voyager/v1beta1.VaultAppRoleAuth"),
+							},
+						},
+					},
+				},
+			},
+			Dependencies: []string{
+				"github.com/appscode/voyager/apis/voyager/v1beta1.VaultAppRoleAuth", "github.com/appscode/voyager/apis/voyager/v1beta1.VaultKubernetesAuth"},
+		},
+		"github.com/appscode/voyager/apis/voyager/v1beta1.VaultIssuer": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"path": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Path is the mount path of the PKI secrets engine. Default is pki.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"role": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Role is the name of the PKI role used to issue the certificate.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+					},
+					Required: []string{"role"},
+				},
+			},
+			Dependencies: []string{},
+		},
+		"github.com/appscode/voyager/apis/voyager/v1beta1.VaultKubernetesAuth": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"role": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Role is the name of the Vault role bound to the service accounts.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"mountPath": {
+							SchemaProps: spec.SchemaProps{
+								Description: "MountPath of the kubernetes auth method. Default is kubernetes.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+					},
+					Required: []string{"role"},
+				},
+			},
+			Dependencies: []string{},
+		},
 		"github.com/appscode/voyager/apis/voyager/v1beta1.VaultStore": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
@@ -1392,10 +2199,24 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Format: "",
 							},
 						},
+						"namespace": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Namespace is the Vault Enterprise namespace of the secrets. Default is VAULT_NAMESPACE of operator.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"auth": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Auth is the Vault auth method used to store the certificate. Default is the auth method configured for operator via VAULT_TOKEN or VAULT_KUBERNETES_ROLE environment variables.",
+								Ref:         ref("github.com/appscode/voyager/apis/voyager/v1beta1.VaultAuth"),
+							},
+						},
 					},
 				},
 			},
-			Dependencies: []string{},
+			Dependencies: []string{
+				"github.com/appscode/voyager/apis/voyager/v1beta1.VaultAuth"},
 		},
 		"github.com/appscode/voyager/apis/voyager/v1beta1.statsService": {
 			Schema: spec.Schema{
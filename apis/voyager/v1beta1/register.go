@@ -35,6 +35,9 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&Certificate{},
 		&CertificateList{},
 
+		&ClusterIssuer{},
+		&ClusterIssuerList{},
+
 		&Ingress{},
 		&IngressList{},
 	)
@@ -82,6 +82,33 @@ func (r Ingress) IsValid(cloudProvider string) error {
 				return errors.Errorf("spec.tls[%d] specifies no secret name and secret ref name", ti)
 			}
 		}
+		if tls.Auth != nil {
+			if tls.Auth.SecretName == "" {
+				return errors.Errorf("spec.tls[%d].auth specifies no secret name", ti)
+			}
+			if tls.Auth.VerifyClient != "" && tls.Auth.VerifyClient != TLSAuthVerifyOptional && tls.Auth.VerifyClient != TLSAuthVerifyRequired {
+				return errors.Errorf("spec.tls[%d].auth.verifyClient %s is unsupported", ti, tls.Auth.VerifyClient)
+			}
+			if len(tls.Auth.Headers) > 0 || tls.Auth.ErrorPage != "" {
+				return errors.Errorf("spec.tls[%d].auth supports only secretName and verifyClient", ti)
+			}
+		}
+		for _, proto := range tls.ALPN {
+			if proto == "" || strings.ContainsAny(proto, ", \t") {
+				return errors.Errorf("spec.tls[%d].alpn %q is invalid", ti, proto)
+			}
+		}
+		if strings.ContainsAny(tls.Ciphers, " \t\n[]") {
+			return errors.Errorf("spec.tls[%d].ciphers %s is invalid", ti, tls.Ciphers)
+		}
+	}
+	if ref := r.Spec.DefaultCertificate; ref != nil {
+		if ref.Kind != "" && !(strings.EqualFold(ref.Kind, "Secret") || strings.EqualFold(ref.Kind, "Certificate")) {
+			return errors.Errorf("spec.defaultCertificate.kind %s is unsupported", ref.Kind)
+		}
+		if ref.Name == "" {
+			return errors.New("spec.defaultCertificate specifies no name")
+		}
 	}
 
 	addrs := make(map[string]*address)
@@ -374,42 +401,174 @@ func (c Certificate) IsValid(cloudProvider string) error {
 		return errors.Errorf("doamin list is empty")
 	}
 
-	if c.Spec.ChallengeProvider.HTTP == nil && c.Spec.ChallengeProvider.DNS == nil {
+	if c.Spec.Issuer != nil {
+		issuers := 0
+		if c.Spec.Issuer.ACME != nil {
+			issuers++
+		}
+		if c.Spec.Issuer.SelfSigned != nil {
+			issuers++
+		}
+		if c.Spec.Issuer.CA != nil {
+			issuers++
+			if c.Spec.Issuer.CA.SecretName == "" {
+				return errors.Errorf("ca issuer specifies no secret name")
+			}
+		}
+		if c.Spec.Issuer.Vault != nil {
+			issuers++
+			if c.Spec.Issuer.Vault.Role == "" {
+				return errors.Errorf("vault issuer specifies no role")
+			}
+		}
+		if issuers != 1 {
+			return errors.Errorf("issuer must specify exactly one of acme, selfSigned, ca and vault")
+		}
+	}
+
+	if c.UsesACME() {
+		if err := c.isValidACME(cloudProvider); err != nil {
+			return err
+		}
+	} else if c.Spec.Duration != nil && c.Spec.Duration.Duration <= 0 {
+		return errors.Errorf("duration %s is invalid", c.Spec.Duration.Duration)
+	}
+
+	if c.Spec.Storage.Secret != nil && c.Spec.Storage.Vault != nil {
+		return errors.Errorf("invalid storage specification, used both storage")
+	}
+	if c.Spec.Storage.Vault != nil && c.Spec.Storage.Vault.Auth != nil {
+		auth := c.Spec.Storage.Vault.Auth
+		if (auth.Kubernetes == nil) == (auth.AppRole == nil) {
+			return errors.Errorf("vault auth must specify exactly one of kubernetes and appRole")
+		}
+		if auth.Kubernetes != nil && auth.Kubernetes.Role == "" {
+			return errors.Errorf("vault kubernetes auth specifies no role")
+		}
+		if auth.AppRole != nil && auth.AppRole.SecretName == "" {
+			return errors.Errorf("vault appRole auth specifies no secret name")
+		}
+	}
+
+	switch alg, size := c.KeyParameters(); alg {
+	case KeyAlgorithmRSA:
+		if size != 2048 && size != 4096 {
+			return errors.Errorf("key size %d is unsupported for %s keys", size, alg)
+		}
+	case KeyAlgorithmECDSA:
+		if size != 256 && size != 384 {
+			return errors.Errorf("key size %d is unsupported for %s keys", size, alg)
+		}
+		if c.Spec.DualKey {
+			return errors.Errorf("dual key certificate requires %s key algorithm", KeyAlgorithmRSA)
+		}
+	default:
+		return errors.Errorf("key algorithm %s is unsupported", alg)
+	}
+
+	if _, _, err := c.parseRenewBefore(); err != nil {
+		return errors.Errorf("renewBefore %s is invalid. Reason: %s", c.Spec.RenewBefore, err)
+	}
+
+	if c.Spec.Revoke != nil {
+		if !c.UsesACME() {
+			return errors.Errorf("revoke is supported only for acme issuer")
+		}
+		switch c.Spec.Revoke.Reason {
+		case "", RevocationReasonUnspecified, RevocationReasonKeyCompromise, RevocationReasonAffiliationChanged,
+			RevocationReasonSuperseded, RevocationReasonCessationOfOperation:
+		default:
+			return errors.Errorf("revocation reason %s is unsupported", c.Spec.Revoke.Reason)
+		}
+	}
+
+	switch c.Spec.DeletionPolicy {
+	case "", CertificateDeletionPolicyRetain, CertificateDeletionPolicyDelete:
+	case CertificateDeletionPolicyRevokeAndDelete:
+		if !c.UsesACME() {
+			return errors.Errorf("deletion policy %s is supported only for acme issuer", c.Spec.DeletionPolicy)
+		}
+	default:
+		return errors.Errorf("deletion policy %s is unsupported", c.Spec.DeletionPolicy)
+	}
+
+	return nil
+}
+
+func (c Certificate) isValidACME(cloudProvider string) error {
+	if c.ClusterIssuerName() != "" {
+		// challenge provider of the ClusterIssuer is used, unless one is set
+		if c.Spec.ChallengeProvider.HTTP == nil && c.Spec.ChallengeProvider.DNS == nil {
+			return nil
+		}
+		return isValidChallengeProvider(c.Spec.ChallengeProvider, cloudProvider)
+	}
+
+	if err := isValidChallengeProvider(c.Spec.ChallengeProvider, cloudProvider); err != nil {
+		return err
+	}
+	if len(c.Spec.ACMEUserSecretName) == 0 {
+		return errors.Errorf("no user secret name specified")
+	}
+	return nil
+}
+
+// IsValid checks the ClusterIssuer. Its challenge provider is optional, since Certificates may set one.
+func (c ClusterIssuer) IsValid(cloudProvider string) error {
+	if len(c.Spec.ACMEUserSecretName) == 0 {
+		return errors.Errorf("no user secret name specified")
+	}
+	if c.Spec.ChallengeProvider.HTTP != nil {
+		return errors.Errorf("http challenge provider is not supported for cluster issuer")
+	}
+	if c.Spec.ChallengeProvider.DNS != nil {
+		return isValidChallengeProvider(c.Spec.ChallengeProvider, cloudProvider)
+	}
+	return nil
+}
+
+func isValidChallengeProvider(p ChallengeProvider, cloudProvider string) error {
+	if p.HTTP == nil && p.DNS == nil {
 		return errors.Errorf("certificate has no valid challange provider")
 	}
 
-	if c.Spec.ChallengeProvider.HTTP != nil && c.Spec.ChallengeProvider.DNS != nil {
+	if p.HTTP != nil && p.DNS != nil {
 		return errors.Errorf("invalid provider specification, used both http and dns provider")
 	}
 
-	if c.Spec.ChallengeProvider.HTTP != nil {
-		if len(c.Spec.ChallengeProvider.HTTP.Ingress.Name) == 0 ||
-			(c.Spec.ChallengeProvider.HTTP.Ingress.APIVersion != SchemeGroupVersion.String() && c.Spec.ChallengeProvider.HTTP.Ingress.APIVersion != "extensions/v1beta1") {
+	if p.HTTP != nil {
+		if len(p.HTTP.Ingress.Name) == 0 ||
+			(p.HTTP.Ingress.APIVersion != SchemeGroupVersion.String() && p.HTTP.Ingress.APIVersion != "extensions/v1beta1") {
 			return errors.Errorf("invalid ingress reference")
 		}
 	}
 
-	if c.Spec.ChallengeProvider.DNS != nil {
-		if len(c.Spec.ChallengeProvider.DNS.Provider) == 0 {
+	if p.DNS != nil {
+		if len(p.DNS.Provider) == 0 {
 			return errors.Errorf("no dns provider name specified")
 		}
-		if c.Spec.ChallengeProvider.DNS.CredentialSecretName == "" {
-			useCredentialFromEnv := (cloudProvider == "aws" && sets.NewString("aws", "route53").Has(c.Spec.ChallengeProvider.DNS.Provider) && c.Spec.ChallengeProvider.DNS.CredentialSecretName == "") ||
-				(sets.NewString("gce", "gke").Has(cloudProvider) && sets.NewString("googlecloud", "gcloud", "gce", "gke").Has(c.Spec.ChallengeProvider.DNS.Provider) && c.Spec.ChallengeProvider.DNS.CredentialSecretName == "")
+		if p.DNS.Provider == "webhook" {
+			webhook := p.DNS.Webhook
+			if webhook == nil || (webhook.URL == "") == (webhook.Service == nil) {
+				return errors.Errorf("webhook dns provider must specify exactly one of url and service")
+			}
+			if webhook.Service != nil {
+				if webhook.Service.Name == "" {
+					return errors.Errorf("webhook dns provider specifies no service name")
+				}
+				if webhook.Service.Scheme != "" && webhook.Service.Scheme != "http" && webhook.Service.Scheme != "https" {
+					return errors.Errorf("webhook dns provider scheme %s is invalid", webhook.Service.Scheme)
+				}
+			}
+		} else if p.DNS.CredentialSecretName == "" {
+			useCredentialFromEnv := (cloudProvider == "aws" && sets.NewString("aws", "route53").Has(p.DNS.Provider) && p.DNS.CredentialSecretName == "") ||
+				(sets.NewString("gce", "gke").Has(cloudProvider) && sets.NewString("googlecloud", "gcloud", "gce", "gke").Has(p.DNS.Provider) && p.DNS.CredentialSecretName == "")
 			if !useCredentialFromEnv {
 				return errors.Errorf("missing dns challenge provider credential")
 			}
 		}
 	}
 
-	if len(c.Spec.ACMEUserSecretName) == 0 {
-		return errors.Errorf("no user secret name specified")
-	}
-
-	if c.Spec.Storage.Secret != nil && c.Spec.Storage.Vault != nil {
-		return errors.Errorf("invalid storage specification, used both storage")
-	}
-
 	return nil
 }
 
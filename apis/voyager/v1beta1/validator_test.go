@@ -803,4 +803,274 @@ var dataTables = map[*Ingress]bool{
 			},
 		},
 	}: false, // conflicting TLS merging "*" host with empty-host
+	{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "Per TLS ALPN, ciphers and auth",
+			Namespace: "default",
+		},
+		Spec: IngressSpec{
+			TLS: []IngressTLS{
+				{
+					Ref: &LocalTypedReference{
+						Kind: "Secret",
+						Name: "voyager-cert",
+					},
+					Hosts: []string{
+						"secure.example.com",
+					},
+					ALPN:    []string{"h2", "http/1.1"},
+					Ciphers: "ECDHE-RSA-AES128-GCM-SHA256:ECDHE-RSA-AES256-GCM-SHA384",
+					Auth: &TLSAuth{
+						SecretName:   "client-ca",
+						VerifyClient: TLSAuthVerifyRequired,
+					},
+				},
+			},
+			Rules: []IngressRule{
+				{
+					Host: "secure.example.com",
+					IngressRuleValue: IngressRuleValue{
+						HTTP: &HTTPIngressRuleValue{
+							Paths: []HTTPIngressPath{
+								{
+									Backend: HTTPIngressBackend{
+										IngressBackend: IngressBackend{
+											ServiceName: "foo",
+											ServicePort: intstr.FromInt(80),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}: true,
+	{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "Per TLS auth without secret",
+			Namespace: "default",
+		},
+		Spec: IngressSpec{
+			TLS: []IngressTLS{
+				{
+					Ref: &LocalTypedReference{
+						Kind: "Secret",
+						Name: "voyager-cert",
+					},
+					Hosts: []string{
+						"secure.example.com",
+					},
+					Auth: &TLSAuth{
+						VerifyClient: TLSAuthVerifyOptional,
+					},
+				},
+			},
+			Rules: []IngressRule{
+				{
+					Host: "secure.example.com",
+					IngressRuleValue: IngressRuleValue{
+						HTTP: &HTTPIngressRuleValue{
+							Paths: []HTTPIngressPath{
+								{
+									Backend: HTTPIngressBackend{
+										IngressBackend: IngressBackend{
+											ServiceName: "foo",
+											ServicePort: intstr.FromInt(80),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}: false,
+	{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "Per TLS auth with error page",
+			Namespace: "default",
+		},
+		Spec: IngressSpec{
+			TLS: []IngressTLS{
+				{
+					Ref: &LocalTypedReference{
+						Kind: "Secret",
+						Name: "voyager-cert",
+					},
+					Hosts: []string{
+						"secure.example.com",
+					},
+					Auth: &TLSAuth{
+						SecretName: "client-ca",
+						ErrorPage:  "https://example.com/error",
+					},
+				},
+			},
+			Rules: []IngressRule{
+				{
+					Host: "secure.example.com",
+					IngressRuleValue: IngressRuleValue{
+						HTTP: &HTTPIngressRuleValue{
+							Paths: []HTTPIngressPath{
+								{
+									Backend: HTTPIngressBackend{
+										IngressBackend: IngressBackend{
+											ServiceName: "foo",
+											ServicePort: intstr.FromInt(80),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}: false,
+	{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "Per TLS auth with invalid verify option",
+			Namespace: "default",
+		},
+		Spec: IngressSpec{
+			TLS: []IngressTLS{
+				{
+					Ref: &LocalTypedReference{
+						Kind: "Secret",
+						Name: "voyager-cert",
+					},
+					Hosts: []string{
+						"secure.example.com",
+					},
+					Auth: &TLSAuth{
+						SecretName:   "client-ca",
+						VerifyClient: "none",
+					},
+				},
+			},
+			Rules: []IngressRule{
+				{
+					Host: "secure.example.com",
+					IngressRuleValue: IngressRuleValue{
+						HTTP: &HTTPIngressRuleValue{
+							Paths: []HTTPIngressPath{
+								{
+									Backend: HTTPIngressBackend{
+										IngressBackend: IngressBackend{
+											ServiceName: "foo",
+											ServicePort: intstr.FromInt(80),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}: false,
+	{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "Per TLS invalid ALPN",
+			Namespace: "default",
+		},
+		Spec: IngressSpec{
+			TLS: []IngressTLS{
+				{
+					Ref: &LocalTypedReference{
+						Kind: "Secret",
+						Name: "voyager-cert",
+					},
+					Hosts: []string{
+						"secure.example.com",
+					},
+					ALPN: []string{"h2,http/1.1"},
+				},
+			},
+			Rules: []IngressRule{
+				{
+					Host: "secure.example.com",
+					IngressRuleValue: IngressRuleValue{
+						HTTP: &HTTPIngressRuleValue{
+							Paths: []HTTPIngressPath{
+								{
+									Backend: HTTPIngressBackend{
+										IngressBackend: IngressBackend{
+											ServiceName: "foo",
+											ServicePort: intstr.FromInt(80),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}: false,
+	{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "Default certificate",
+			Namespace: "default",
+		},
+		Spec: IngressSpec{
+			DefaultCertificate: &LocalTypedReference{
+				Kind: "Certificate",
+				Name: "default-cert",
+			},
+			Rules: []IngressRule{
+				{
+					Host: "secure.example.com",
+					IngressRuleValue: IngressRuleValue{
+						HTTP: &HTTPIngressRuleValue{
+							Paths: []HTTPIngressPath{
+								{
+									Backend: HTTPIngressBackend{
+										IngressBackend: IngressBackend{
+											ServiceName: "foo",
+											ServicePort: intstr.FromInt(80),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}: true,
+	{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "Default certificate with invalid kind",
+			Namespace: "default",
+		},
+		Spec: IngressSpec{
+			DefaultCertificate: &LocalTypedReference{
+				Kind: "ConfigMap",
+				Name: "default-cert",
+			},
+			Rules: []IngressRule{
+				{
+					Host: "secure.example.com",
+					IngressRuleValue: IngressRuleValue{
+						HTTP: &HTTPIngressRuleValue{
+							Paths: []HTTPIngressPath{
+								{
+									Backend: HTTPIngressBackend{
+										IngressBackend: IngressBackend{
+											ServiceName: "foo",
+											ServicePort: intstr.FromInt(80),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}: false,
 }
@@ -32,6 +32,10 @@ func (c *FakeVoyagerV1beta1) Certificates(namespace string) v1beta1.CertificateI
 	return &FakeCertificates{c, namespace}
 }
 
+func (c *FakeVoyagerV1beta1) ClusterIssuers() v1beta1.ClusterIssuerInterface {
+	return &FakeClusterIssuers{c}
+}
+
 func (c *FakeVoyagerV1beta1) Ingresses(namespace string) v1beta1.IngressInterface {
 	return &FakeIngresses{c, namespace}
 }
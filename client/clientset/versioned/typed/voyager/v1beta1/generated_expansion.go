@@ -20,4 +20,6 @@ package v1beta1
 
 type CertificateExpansion interface{}
 
+type ClusterIssuerExpansion interface{}
+
 type IngressExpansion interface{}
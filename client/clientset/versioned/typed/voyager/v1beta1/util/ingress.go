@@ -9,6 +9,7 @@ import (
 	"github.com/evanphx/json-patch"
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
 	kerr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -81,22 +82,69 @@ func TryUpdateIngress(c cs.VoyagerV1beta1Interface, meta metav1.ObjectMeta, tran
 	return
 }
 
-func UpdateIngressStatus(c cs.VoyagerV1beta1Interface, cur *api.Ingress, transform func(*api.IngressStatus) *api.IngressStatus, useSubresource ...bool) (*api.Ingress, error) {
+// patchIngressStatus patches only the status of an Ingress, with the resourceVersion of cur as precondition.
+// So, it fails with conflict instead of overwriting the status written by others since cur was read.
+func patchIngressStatus(c cs.VoyagerV1beta1Interface, cur, mod *api.Ingress) (*api.Ingress, error) {
+	curJson, err := json.Marshal(api.Ingress{Status: cur.Status})
+	if err != nil {
+		return nil, err
+	}
+	modJson, err := json.Marshal(api.Ingress{
+		ObjectMeta: metav1.ObjectMeta{ResourceVersion: cur.ResourceVersion},
+		Status:     mod.Status,
+	})
+	if err != nil {
+		return nil, err
+	}
+	patch, err := jsonpatch.CreateMergePatch(curJson, modJson)
+	if err != nil {
+		return nil, err
+	}
+	glog.V(3).Infof("Patching status of Ingress %s/%s with %s.", cur.Namespace, cur.Name, string(patch))
+	return c.Ingresses(cur.Namespace).Patch(cur.Name, types.MergePatchType, patch)
+}
+
+// UpdateIngressStatus writes the status returned by transform. On conflict, it gets the latest Ingress and applies
+// transform to its status again, so transform must only set the fields and conditions owned by its caller.
+func UpdateIngressStatus(c cs.VoyagerV1beta1Interface, in *api.Ingress, transform func(*api.IngressStatus) *api.IngressStatus, useSubresource ...bool) (result *api.Ingress, err error) {
 	if len(useSubresource) > 1 {
 		return nil, errors.Errorf("invalid value passed for useSubresource: %v", useSubresource)
 	}
 
-	mod := &api.Ingress{
-		TypeMeta:   cur.TypeMeta,
-		ObjectMeta: cur.ObjectMeta,
-		Spec:       cur.Spec,
-		Status:     *transform(cur.Status.DeepCopy()),
-	}
+	attempt := 0
+	cur := in
+	err = wait.PollImmediate(kutil.RetryInterval, kutil.RetryTimeout, func() (bool, error) {
+		attempt++
+		mod := cur.DeepCopy()
+		mod.Status = *transform(cur.Status.DeepCopy())
+		if equality.Semantic.DeepEqual(cur.Status, mod.Status) {
+			result = cur
+			return true, nil
+		}
 
-	if len(useSubresource) == 1 && useSubresource[0] {
-		return c.Ingresses(cur.Namespace).UpdateStatus(mod)
-	}
+		var e2 error
+		if len(useSubresource) == 1 && useSubresource[0] {
+			result, e2 = c.Ingresses(cur.Namespace).UpdateStatus(mod)
+		} else {
+			result, e2 = patchIngressStatus(c, cur, mod)
+		}
+		if e2 == nil {
+			return true, nil
+		} else if kerr.IsNotFound(e2) {
+			return false, e2
+		} else if kerr.IsConflict(e2) {
+			latest, e3 := c.Ingresses(cur.Namespace).Get(cur.Name, metav1.GetOptions{})
+			if e3 != nil {
+				return false, e3
+			}
+			cur = latest
+		}
+		glog.Errorf("Attempt %d failed to update status of Ingress %s/%s due to %v.", attempt, cur.Namespace, cur.Name, e2)
+		return false, nil
+	})
 
-	out, _, err := PatchIngressObject(c, cur, mod)
-	return out, err
+	if err != nil {
+		err = errors.Errorf("failed to update status of Ingress %s/%s after %d attempts due to %v", in.Namespace, in.Name, attempt, err)
+	}
+	return
 }
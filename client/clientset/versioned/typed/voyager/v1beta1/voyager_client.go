@@ -28,6 +28,7 @@ import (
 type VoyagerV1beta1Interface interface {
 	RESTClient() rest.Interface
 	CertificatesGetter
+	ClusterIssuersGetter
 	IngressesGetter
 }
 
@@ -40,6 +41,10 @@ func (c *VoyagerV1beta1Client) Certificates(namespace string) CertificateInterfa
 	return newCertificates(c, namespace)
 }
 
+func (c *VoyagerV1beta1Client) ClusterIssuers() ClusterIssuerInterface {
+	return newClusterIssuers(c)
+}
+
 func (c *VoyagerV1beta1Client) Ingresses(namespace string) IngressInterface {
 	return newIngresses(c, namespace)
 }
@@ -55,6 +55,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=voyager.appscode.com, Version=v1beta1
 	case v1beta1.SchemeGroupVersion.WithResource("certificates"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Voyager().V1beta1().Certificates().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("clusterissuers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Voyager().V1beta1().ClusterIssuers().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("ingresses"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Voyager().V1beta1().Ingresses().Informer()}, nil
 
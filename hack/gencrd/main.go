@@ -34,6 +34,7 @@ func generateCRDDefinitions() {
 	crds := []*crd_api.CustomResourceDefinition{
 		api.Ingress{}.CustomResourceDefinition(),
 		api.Certificate{}.CustomResourceDefinition(),
+		api.ClusterIssuer{}.CustomResourceDefinition(),
 	}
 	for _, crd := range crds {
 		crdutils.MarshallCrd(f, crd, "yaml")
@@ -72,6 +73,7 @@ func generateSwaggerJson() {
 		},
 		Resources: []schema.GroupVersionResource{
 			v1beta1.SchemeGroupVersion.WithResource(v1beta1.ResourcePluralCertificate),
+			v1beta1.SchemeGroupVersion.WithResource(v1beta1.ResourcePluralClusterIssuer),
 			v1beta1.SchemeGroupVersion.WithResource(v1beta1.ResourcePluralIngress),
 		},
 	})
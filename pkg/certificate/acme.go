@@ -2,12 +2,14 @@ package certificate
 
 import (
 	"crypto"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
 	"github.com/appscode/voyager/pkg/certificate/providers"
 	"github.com/pkg/errors"
 	"github.com/xenolf/lego/acmev2"
@@ -35,7 +37,7 @@ const (
 )
 
 func (c *Controller) newACMEClient() (*acme.Client, error) {
-	client, err := acme.NewClient(c.acmeUser.getServerURL(), c.acmeUser, acme.RSA2048)
+	client, err := acme.NewClient(c.acmeUser.getServerURL(), c.acmeUser, keyType(c.crd))
 	if err != nil {
 		return nil, err
 	}
@@ -44,7 +46,7 @@ func (c *Controller) newACMEClient() (*acme.Client, error) {
 		if err != nil {
 			return nil, err
 		}
-		if err := client.SetChallengeProvider(acme.DNS01, provider); err != nil {
+		if err := client.SetChallengeProvider(acme.DNS01, c.withSelfCheck(provider, c.checkDNSChallenge)); err != nil {
 			return nil, err
 		}
 		client.ExcludeChallenges([]acme.Challenge{acme.HTTP01})
@@ -59,7 +61,7 @@ func (c *Controller) newACMEClient() (*acme.Client, error) {
 
 	switch strings.ToLower(c.ChallengeProvider) {
 	case "http":
-		if err := client.SetChallengeProvider(acme.HTTP01, providers.DefaultHTTPProvider()); err != nil {
+		if err := client.SetChallengeProvider(acme.HTTP01, c.withSelfCheck(providers.DefaultHTTPProvider(), c.checkHTTPChallenge)); err != nil {
 			return nil, err
 		}
 		client.ExcludeChallenges([]acme.Challenge{acme.DNS01})
@@ -259,6 +261,34 @@ func (c *Controller) newACMEClient() (*acme.Client, error) {
 			return nil, err
 		}
 		return newDNSProvider(pdns.NewDNSProviderCredentials(hostUrl, key))
+	case "rfc2136":
+		var nameserver string
+		if nameserver, found = dnsLoader("RFC2136_NAMESERVER"); !found {
+			return nil, errors.Errorf("dns provider credential missing key %s", "RFC2136_NAMESERVER")
+		}
+		zone, _ := dnsLoader("RFC2136_ZONE")
+		tsigAlgorithm, _ := dnsLoader("RFC2136_TSIG_ALGORITHM")
+		tsigKey, _ := dnsLoader("RFC2136_TSIG_KEY")
+		tsigSecret, _ := dnsLoader("RFC2136_TSIG_SECRET")
+		return newDNSProvider(providers.NewRFC2136Provider(nameserver, zone, tsigAlgorithm, tsigKey, tsigSecret))
+	case "webhook":
+		url := c.challenge.DNS.Webhook.URL
+		if svc := c.challenge.DNS.Webhook.Service; svc != nil {
+			scheme, port := svc.Scheme, svc.Port
+			if scheme == "" {
+				scheme = "https"
+			}
+			if port == 0 {
+				port = 443
+			}
+			url = fmt.Sprintf("%s://%s.%s.svc:%d/%s", scheme, svc.Name, c.challengeNamespace, port, strings.TrimPrefix(svc.Path, "/"))
+		}
+		// all credentials are optional
+		token, _ := dnsLoader("WEBHOOK_TOKEN")
+		caCert, _ := dnsLoader("WEBHOOK_CA_CERT")
+		clientCert, _ := dnsLoader("WEBHOOK_CLIENT_CERT")
+		clientKey, _ := dnsLoader("WEBHOOK_CLIENT_KEY")
+		return newDNSProvider(providers.NewWebhookProvider(url, token, []byte(caCert), []byte(clientCert), []byte(clientKey)))
 	case "vultr":
 		var apiKey string
 		if apiKey, found = dnsLoader("VULTR_API_KEY"); !found {
@@ -270,11 +300,30 @@ func (c *Controller) newACMEClient() (*acme.Client, error) {
 	}
 }
 
+// keyType returns the type of private key generated by lego for new certificates.
+func keyType(crd *api.Certificate) acme.KeyType {
+	alg, size := crd.KeyParameters()
+	switch {
+	case alg == api.KeyAlgorithmECDSA && size == 384:
+		return acme.EC384
+	case alg == api.KeyAlgorithmECDSA:
+		return acme.EC256
+	case size == 4096:
+		return acme.RSA4096
+	}
+	return acme.RSA2048
+}
+
 type ACMEUser struct {
 	ServerURL    string
 	Email        string
 	Registration *acme.RegistrationResource
 	Key          crypto.PrivateKey
+	// CABundle is used to verify a private ACME server
+	CABundle []byte
+	// ExternalAccountKeyID and ExternalAccountHMACKey bind the account to an account of the CA
+	ExternalAccountKeyID   string
+	ExternalAccountHMACKey string
 }
 
 var _ acme.User = &ACMEUser{}
@@ -0,0 +1,331 @@
+package certificate
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/xenolf/lego/acmev2"
+	"gopkg.in/square/go-jose.v2"
+	"k8s.io/client-go/util/cert"
+)
+
+// maxACMEResponseSize limits the size of responses read from ACME servers.
+const maxACMEResponseSize = 1 << 20
+
+// acmeAccount sends the requests of an ACME account that lego does not support: registration with
+// External Account Binding, revocation with a reason and download of alternate certificate chains.
+// It only needs the ACME user, so certificates can be revoked without the challenge provider.
+// ref: https://tools.ietf.org/html/rfc8555
+type acmeAccount struct {
+	client    *http.Client
+	directory acmeDirectory
+	key       crypto.PrivateKey
+	kid       string // account url, empty until registered
+}
+
+type acmeDirectory struct {
+	NewNonceURL   string `json:"newNonce"`
+	NewAccountURL string `json:"newAccount"`
+	RevokeCertURL string `json:"revokeCert"`
+	Meta          struct {
+		ExternalAccountRequired bool `json:"externalAccountRequired"`
+	} `json:"meta"`
+}
+
+type acmeAccountMessage struct {
+	Contact                []string        `json:"contact"`
+	TermsOfServiceAgreed   bool            `json:"termsOfServiceAgreed"`
+	ExternalAccountBinding json.RawMessage `json:"externalAccountBinding,omitempty"`
+}
+
+type acmeRevokeMessage struct {
+	Certificate string `json:"certificate"`
+	Reason      uint   `json:"reason,omitempty"`
+}
+
+func newACMEAccount(user *ACMEUser) (*acmeAccount, error) {
+	client, err := newACMEHTTPClient(user.CABundle)
+	if err != nil {
+		return nil, err
+	}
+	a := &acmeAccount{client: client, key: user.Key}
+	if user.Registration != nil {
+		a.kid = user.Registration.URI
+	}
+
+	resp, err := a.get(user.getServerURL())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err = json.NewDecoder(io.LimitReader(resp.Body, maxACMEResponseSize)).Decode(&a.directory); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode acme directory %s", user.getServerURL())
+	}
+	if a.directory.NewNonceURL == "" || a.directory.NewAccountURL == "" {
+		return nil, errors.Errorf("acme directory %s is missing newNonce or newAccount url", user.getServerURL())
+	}
+	return a, nil
+}
+
+// register creates the account. If keyID is given, the account is bound to the account keyID of
+// the CA, using the base64url encoded hmacKey.
+// ref: https://tools.ietf.org/html/rfc8555#section-7.3.4
+func (a *acmeAccount) register(email, keyID, hmacKey string) (*acme.RegistrationResource, error) {
+	msg := acmeAccountMessage{
+		Contact:              []string{},
+		TermsOfServiceAgreed: true,
+	}
+	if email != "" {
+		msg.Contact = []string{"mailto:" + email}
+	}
+	if keyID != "" {
+		eab, err := a.externalAccountBinding(keyID, hmacKey)
+		if err != nil {
+			return nil, err
+		}
+		msg.ExternalAccountBinding = eab
+	}
+
+	var body json.RawMessage
+	hdr, err := a.post(a.directory.NewAccountURL, msg, &body)
+	if err != nil {
+		return nil, err
+	}
+	location := hdr.Get("Location")
+	if location == "" {
+		return nil, errors.New("acme server returned no account url")
+	}
+	a.kid = location
+
+	// Body of lego's RegistrationResource has an unexported type, so it is set via json
+	data, err := json.Marshal(map[string]interface{}{"body": body, "uri": location})
+	if err != nil {
+		return nil, err
+	}
+	var reg acme.RegistrationResource
+	if err = json.Unmarshal(data, &reg); err != nil {
+		return nil, err
+	}
+	return &reg, nil
+}
+
+func (a *acmeAccount) externalAccountBinding(keyID, hmacKey string) (json.RawMessage, error) {
+	mac, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(hmacKey, "="))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode hmac key of external account")
+	}
+	signer, ok := a.key.(crypto.Signer)
+	if !ok {
+		return nil, errors.Errorf("unsupported acme account key type %T", a.key)
+	}
+	jwk, err := (&jose.JSONWebKey{Key: signer.Public()}).MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	eabSigner, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: mac}, &jose.SignerOptions{
+		ExtraHeaders: map[jose.HeaderKey]interface{}{
+			"kid": keyID,
+			"url": a.directory.NewAccountURL,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	signed, err := eabSigner.Sign(jwk)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign external account binding")
+	}
+	return json.RawMessage(signed.FullSerialize()), nil
+}
+
+// revoke revokes the first certificate of the PEM encoded pemCrt with the RFC 5280 reason code.
+// Reason code 0 (unspecified) is not sent.
+// ref: https://tools.ietf.org/html/rfc8555#section-7.6
+func (a *acmeAccount) revoke(pemCrt []byte, reason uint) error {
+	if a.directory.RevokeCertURL == "" {
+		return errors.New("acme server does not support revocation")
+	}
+	certs, err := cert.ParseCertsPEM(pemCrt)
+	if err != nil {
+		return err
+	}
+	_, err = a.post(a.directory.RevokeCertURL, acmeRevokeMessage{
+		Certificate: base64.RawURLEncoding.EncodeToString(certs[0].Raw),
+		Reason:      reason,
+	}, nil)
+	return err
+}
+
+// preferredChain returns the chain of the certificate at certURL whose top-most certificate is issued
+// by a CA with the common name issuerCN, among chain and the alternate chains offered by the server.
+// If none matches, chain is returned.
+// ref: https://tools.ietf.org/html/rfc8555#section-7.4.2
+func (a *acmeAccount) preferredChain(certURL string, chain []byte, issuerCN string) ([]byte, error) {
+	if chainIssuedBy(chain, issuerCN) {
+		return chain, nil
+	}
+	resp, err := a.get(certURL)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	for _, link := range alternateLinks(resp.Header["Link"]) {
+		resp, err := a.get(link)
+		if err != nil {
+			return nil, err
+		}
+		alt, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxACMEResponseSize))
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if chainIssuedBy(alt, issuerCN) {
+			return alt, nil
+		}
+	}
+	return chain, nil
+}
+
+// chainIssuedBy returns true if the top-most certificate of the PEM encoded chain is issued by a CA
+// with the common name issuerCN.
+func chainIssuedBy(chain []byte, issuerCN string) bool {
+	certs, err := cert.ParseCertsPEM(chain)
+	return err == nil && certs[len(certs)-1].Issuer.CommonName == issuerCN
+}
+
+// alternateLinks returns the urls of Link headers with relation "alternate".
+func alternateLinks(headers []string) []string {
+	var urls []string
+	for _, header := range headers {
+		for _, link := range strings.Split(header, ",") {
+			parts := strings.Split(link, ";")
+			for _, param := range parts[1:] {
+				if strings.Replace(strings.TrimSpace(param), " ", "", -1) == `rel="alternate"` {
+					urls = append(urls, strings.Trim(strings.TrimSpace(parts[0]), "<>"))
+				}
+			}
+		}
+	}
+	return urls
+}
+
+func (a *acmeAccount) get(url string) (*http.Response, error) {
+	resp, err := a.client.Get(url)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get %s", url)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		return nil, acmeError(resp)
+	}
+	return resp, nil
+}
+
+// post sends payload as JWS signed by the account key and decodes the response into out, if given.
+// The request is retried once, if the server rejects the nonce.
+func (a *acmeAccount) post(url string, payload, out interface{}) (http.Header, error) {
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; ; i++ {
+		body, err := a.sign(url, content)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := a.client.Post(url, "application/jose+json", bytes.NewBufferString(body))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to post to %s", url)
+		}
+		if resp.StatusCode >= http.StatusBadRequest {
+			err = acmeError(resp)
+			resp.Body.Close()
+			if e, ok := err.(acme.RemoteError); ok && e.Type == "urn:ietf:params:acme:error:badNonce" && i == 0 {
+				continue
+			}
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if out != nil {
+			if err = json.NewDecoder(io.LimitReader(resp.Body, maxACMEResponseSize)).Decode(out); err != nil {
+				return nil, errors.Wrapf(err, "failed to decode response of %s", url)
+			}
+		}
+		return resp.Header, nil
+	}
+}
+
+// sign signs content with the account key. The JWS includes the account url as kid once registered,
+// otherwise the public key of account.
+func (a *acmeAccount) sign(url string, content []byte) (string, error) {
+	var alg jose.SignatureAlgorithm
+	switch k := a.key.(type) {
+	case *rsa.PrivateKey:
+		alg = jose.RS256
+	case *ecdsa.PrivateKey:
+		alg = jose.ES256
+		if k.Curve == elliptic.P384() {
+			alg = jose.ES384
+		}
+	default:
+		return "", errors.Errorf("unsupported acme account key type %T", a.key)
+	}
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: alg, Key: jose.JSONWebKey{Key: a.key, KeyID: a.kid}},
+		&jose.SignerOptions{
+			NonceSource:  a,
+			EmbedJWK:     a.kid == "",
+			ExtraHeaders: map[jose.HeaderKey]interface{}{"url": url},
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	signed, err := signer.Sign(content)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to sign request to %s", url)
+	}
+	return signed.FullSerialize(), nil
+}
+
+// Nonce returns a new nonce of the ACME server, as jose.NonceSource.
+func (a *acmeAccount) Nonce() (string, error) {
+	resp, err := a.client.Head(a.directory.NewNonceURL)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get acme nonce")
+	}
+	resp.Body.Close()
+	nonce := resp.Header.Get("Replay-Nonce")
+	if nonce == "" {
+		return "", errors.New("acme server returned no nonce")
+	}
+	return nonce, nil
+}
+
+// acmeError decodes the problem document of a failed response.
+// ref: https://tools.ietf.org/html/rfc8555#section-6.7
+func acmeError(resp *http.Response) error {
+	e := acme.RemoteError{StatusCode: resp.StatusCode}
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxACMEResponseSize))
+	if err != nil {
+		return err
+	}
+	if json.Unmarshal(data, &e) != nil || e.Detail == "" {
+		e.Detail = string(data)
+	}
+	e.StatusCode = resp.StatusCode
+	return e
+}
@@ -0,0 +1,145 @@
+package certificate
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/square/go-jose.v2"
+	"k8s.io/client-go/util/cert"
+)
+
+func TestACMEAccount(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hmacKey := []byte("external-account-mac-key")
+	defaultChain, alternateChain := newTestChain(t, "Default Root"), newTestChain(t, "Preferred Root")
+
+	var server *httptest.Server
+	var revoked acmeRevokeMessage
+	verify := func(r *http.Request, kid string) ([]byte, *jose.JSONWebSignature) {
+		data, _ := ioutil.ReadAll(r.Body)
+		jws, err := jose.ParseSigned(string(data))
+		if !assert.NoError(t, err) {
+			return nil, nil
+		}
+		hdr := jws.Signatures[0].Header
+		assert.Equal(t, "nonce-1", hdr.Nonce)
+		assert.Equal(t, server.URL+r.URL.Path, hdr.ExtraHeaders["url"])
+		assert.Equal(t, kid, hdr.KeyID)
+		if kid == "" {
+			payload, err := jws.Verify(hdr.JSONWebKey)
+			assert.NoError(t, err)
+			return payload, jws
+		}
+		payload, err := jws.Verify(&key.PublicKey)
+		assert.NoError(t, err)
+		return payload, jws
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/directory", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"newNonce":"%[1]s/nonce","newAccount":"%[1]s/account","revokeCert":"%[1]s/revoke","meta":{"externalAccountRequired":true}}`, server.URL)
+	})
+	mux.HandleFunc("/nonce", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Replay-Nonce", "nonce-1")
+	})
+	mux.HandleFunc("/account", func(w http.ResponseWriter, r *http.Request) {
+		payload, jws := verify(r, "")
+		var msg acmeAccountMessage
+		if !assert.NoError(t, json.Unmarshal(payload, &msg)) {
+			return
+		}
+		assert.Equal(t, []string{"mailto:user@example.com"}, msg.Contact)
+		assert.True(t, msg.TermsOfServiceAgreed)
+
+		eab, err := jose.ParseSigned(string(msg.ExternalAccountBinding))
+		if assert.NoError(t, err) {
+			assert.Equal(t, "kid-1", eab.Signatures[0].Header.KeyID)
+			jwk, err := eab.Verify(hmacKey)
+			if assert.NoError(t, err) {
+				expected, _ := jws.Signatures[0].Header.JSONWebKey.MarshalJSON()
+				assert.JSONEq(t, string(expected), string(jwk))
+			}
+		}
+		w.Header().Set("Location", server.URL+"/account/1")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"status":"valid"}`))
+	})
+	mux.HandleFunc("/revoke", func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := verify(r, server.URL+"/account/1")
+		assert.NoError(t, json.Unmarshal(payload, &revoked))
+	})
+	mux.HandleFunc("/cert", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Link", fmt.Sprintf(`<%s/issuer>;rel="up", <%s/cert/1>;rel="alternate"`, server.URL, server.URL))
+		w.Write(defaultChain)
+	})
+	mux.HandleFunc("/cert/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(alternateChain)
+	})
+	server = httptest.NewServer(mux)
+	defer server.Close()
+
+	account, err := newACMEAccount(&ACMEUser{ServerURL: server.URL + "/directory", Key: key})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, account.directory.Meta.ExternalAccountRequired)
+
+	reg, err := account.register("user@example.com", "kid-1", base64.RawURLEncoding.EncodeToString(hmacKey))
+	if assert.NoError(t, err) {
+		assert.Equal(t, server.URL+"/account/1", reg.URI)
+		assert.Equal(t, "valid", reg.Body.Status)
+	}
+
+	if assert.NoError(t, account.revoke(defaultChain, 4)) {
+		crts, _ := cert.ParseCertsPEM(defaultChain)
+		assert.Equal(t, base64.RawURLEncoding.EncodeToString(crts[0].Raw), revoked.Certificate)
+		assert.Equal(t, uint(4), revoked.Reason)
+	}
+
+	chain, err := account.preferredChain(server.URL+"/cert", defaultChain, "Preferred Root")
+	if assert.NoError(t, err) {
+		assert.Equal(t, alternateChain, chain)
+	}
+	chain, err = account.preferredChain(server.URL+"/cert", defaultChain, "Unknown Root")
+	if assert.NoError(t, err) {
+		assert.Equal(t, defaultChain, chain)
+	}
+}
+
+// newTestChain returns a PEM encoded leaf and intermediate certificate, where the intermediate
+// is issued by root.
+func newTestChain(t *testing.T, root string) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sign := func(serial int64, subject, issuer string) []byte {
+		der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: subject},
+			Issuer:       pkix.Name{CommonName: issuer},
+			NotBefore:    time.Now(),
+			NotAfter:     time.Now().Add(time.Hour),
+		}, &x509.Certificate{Subject: pkix.Name{CommonName: issuer}}, &key.PublicKey, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		crt, _ := x509.ParseCertificate(der)
+		return cert.EncodeCertPEM(crt)
+	}
+	return append(sign(1, "example.com", "Intermediate"), sign(2, "Intermediate", root)...)
+}
@@ -0,0 +1,48 @@
+package certificate
+
+import (
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// getClusterIssuer returns the ClusterIssuer used by this Certificate, if its namespace is allowed to use it.
+func (c *Controller) getClusterIssuer(name string) (*api.ClusterIssuer, error) {
+	if c.cfg.OperatorNamespace == "" {
+		return nil, errors.Errorf("cluster issuer %s can't be used since operator namespace is unknown", name)
+	}
+	issuer, err := c.VoyagerClient.VoyagerV1beta1().ClusterIssuers().Get(name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get cluster issuer %s", name)
+	}
+	if err = issuer.IsValid(c.cfg.CloudProvider); err != nil {
+		return nil, errors.Wrapf(err, "cluster issuer %s is invalid", name)
+	}
+
+	ns, err := c.KubeClient.CoreV1().Namespaces().Get(c.crd.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	allowed, err := allowsNamespace(issuer, ns)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.Errorf("cluster issuer %s is not allowed in namespace %s", name, c.crd.Namespace)
+	}
+	return issuer, nil
+}
+
+// allowsNamespace returns true if Certificates of namespace ns may use issuer.
+func allowsNamespace(issuer *api.ClusterIssuer, ns *core.Namespace) (bool, error) {
+	if issuer.Spec.AllowedNamespaces == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(issuer.Spec.AllowedNamespaces)
+	if err != nil {
+		return false, errors.Wrapf(err, "cluster issuer %s has invalid allowedNamespaces", issuer.Name)
+	}
+	return selector.Matches(labels.Set(ns.Labels)), nil
+}
@@ -0,0 +1,36 @@
+package certificate
+
+import (
+	"testing"
+
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAllowsNamespace(t *testing.T) {
+	team := &core.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team", Labels: map[string]string{"acme": "shared"}}}
+	other := &core.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}}
+
+	issuer := &api.ClusterIssuer{ObjectMeta: metav1.ObjectMeta{Name: "letsencrypt"}}
+	for _, ns := range []*core.Namespace{team, other} {
+		allowed, err := allowsNamespace(issuer, ns)
+		assert.NoError(t, err)
+		assert.True(t, allowed, ns.Name)
+	}
+
+	issuer.Spec.AllowedNamespaces = &metav1.LabelSelector{MatchLabels: map[string]string{"acme": "shared"}}
+	allowed, err := allowsNamespace(issuer, team)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+	allowed, err = allowsNamespace(issuer, other)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+
+	issuer.Spec.AllowedNamespaces = &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "acme", Operator: "Bad"}},
+	}
+	_, err = allowsNamespace(issuer, team)
+	assert.Error(t, err)
+}
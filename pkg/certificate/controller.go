@@ -1,6 +1,9 @@
 package certificate
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -13,15 +16,12 @@ import (
 	api "github.com/appscode/voyager/apis/voyager/v1beta1"
 	cs "github.com/appscode/voyager/client/clientset/versioned"
 	"github.com/appscode/voyager/client/clientset/versioned/typed/voyager/v1beta1/util"
-	"github.com/appscode/voyager/pkg/certificate/providers"
 	"github.com/appscode/voyager/pkg/config"
 	"github.com/appscode/voyager/pkg/eventer"
 	"github.com/pkg/errors"
 	"github.com/xenolf/lego/acmev2"
 	core "k8s.io/api/core/v1"
-	extensions "k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/cert"
@@ -35,12 +35,18 @@ type Controller struct {
 
 	crd               *api.Certificate
 	ChallengeProvider string
-	UserSecret        *core.Secret
-	DNSCredentials    map[string][]byte
-	curCert           *x509.Certificate
-	acmeUser          *ACMEUser
-	acmeClient        *acme.Client
-	store             *CertStore
+	// challenge is the challenge provider of the Certificate, or of its ClusterIssuer.
+	// Secrets and services it refers to are in challengeNamespace.
+	challenge          api.ChallengeProvider
+	challengeNamespace string
+	UserSecret         *core.Secret
+	DNSCredentials     map[string][]byte
+	curCert            *x509.Certificate
+	acmeUser           *ACMEUser
+	acmeClient         *acme.Client
+	acmeAccount        *acmeAccount
+	issuer             Issuer
+	store              *CertStore
 }
 
 func NewController(kubeClient kubernetes.Interface, extClient cs.Interface, cfg config.Config, tpr *api.Certificate) (*Controller, error) {
@@ -56,67 +62,118 @@ func NewController(kubeClient kubernetes.Interface, extClient cs.Interface, cfg
 		return nil, err
 	}
 
-	ctrl.UserSecret, err = ctrl.KubeClient.CoreV1().Secrets(ctrl.crd.Namespace).Get(ctrl.crd.Spec.ACMEUserSecretName, metav1.GetOptions{})
+	ctrl.store, err = NewCertStore(kubeClient, extClient)
 	if err != nil {
 		return nil, err
 	}
-	ctrl.acmeUser = &ACMEUser{}
+	if ctrl.store.VaultClient == nil &&
+		(ctrl.crd.Spec.Storage.Vault != nil || (ctrl.crd.Spec.Issuer != nil && ctrl.crd.Spec.Issuer.Vault != nil)) {
+		return nil, errors.Errorf("certificate %s/%s uses vault but vault address is missing", tpr.Namespace, tpr.Name)
+	}
+	if ctrl.crd.Spec.Issuer != nil && ctrl.crd.Spec.Issuer.Vault != nil {
+		// refreshes the token of store.VaultClient used by vault issuer
+		if _, err = ctrl.store.vaultClient(nil, ctrl.crd.Namespace); err != nil {
+			return nil, err
+		}
+	}
+	ctrl.issuer = ctrl.newIssuer()
 
-	if email, ok := ctrl.UserSecret.Data[api.ACMEUserEmail]; !ok {
-		return nil, errors.Errorf("no acme user email is provided")
-	} else {
-		ctrl.acmeUser.Email = strings.TrimSpace(string(email))
+	if ctrl.crd.UsesACME() {
+		if err = ctrl.initACME(); err != nil {
+			return nil, err
+		}
 	}
+	return ctrl, nil
+}
 
-	if u, found := ctrl.UserSecret.Data[api.ACMEServerURL]; found {
-		ctrl.acmeUser.ServerURL = strings.TrimSpace(string(u))
-	} else {
-		ctrl.acmeUser.ServerURL = LetsEncryptProdURL
+// initACME loads the ACME user and challenge provider of a Certificate issued by an ACME server.
+func (c *Controller) initACME() error {
+	c.challenge, c.challengeNamespace = c.crd.Spec.ChallengeProvider, c.crd.Namespace
+	issuer, err := c.loadACMEUser()
+	if err != nil {
+		return err
+	}
+	if issuer != nil && c.challenge.HTTP == nil && c.challenge.DNS == nil {
+		c.challenge, c.challengeNamespace = issuer.Spec.ChallengeProvider, c.cfg.OperatorNamespace
 	}
 
-	if ctrl.crd.Spec.ChallengeProvider.HTTP != nil {
-		ctrl.ChallengeProvider = "http"
-		switch ctrl.crd.Spec.ChallengeProvider.HTTP.Ingress.APIVersion {
+	if c.challenge.HTTP != nil {
+		c.ChallengeProvider = "http"
+		switch c.challenge.HTTP.Ingress.APIVersion {
 		case api.SchemeGroupVersion.String():
 			var err error
-			_, err = ctrl.VoyagerClient.VoyagerV1beta1().Ingresses(ctrl.crd.Namespace).
-				Get(ctrl.crd.Spec.ChallengeProvider.HTTP.Ingress.Name, metav1.GetOptions{})
+			_, err = c.VoyagerClient.VoyagerV1beta1().Ingresses(c.crd.Namespace).
+				Get(c.challenge.HTTP.Ingress.Name, metav1.GetOptions{})
 			if err != nil {
-				return nil, err
+				return err
 			}
 		case "extensions/v1beta1":
-			ing, err := ctrl.KubeClient.ExtensionsV1beta1().Ingresses(ctrl.crd.Namespace).
-				Get(ctrl.crd.Spec.ChallengeProvider.HTTP.Ingress.Name, metav1.GetOptions{})
+			ing, err := c.KubeClient.ExtensionsV1beta1().Ingresses(c.crd.Namespace).
+				Get(c.challenge.HTTP.Ingress.Name, metav1.GetOptions{})
 			if err != nil {
-				return nil, err
+				return err
 			}
 			_, err = api.NewEngressFromIngress(ing)
 			if err != nil {
-				return nil, err
+				return err
 			}
 		default:
-			return nil, errors.New("ingress API Schema unrecognized")
+			return errors.New("ingress API Schema unrecognized")
 		}
-	} else if ctrl.crd.Spec.ChallengeProvider.DNS != nil {
-		ctrl.ChallengeProvider = ctrl.crd.Spec.ChallengeProvider.DNS.Provider
-		if ctrl.crd.Spec.ChallengeProvider.DNS.CredentialSecretName != "" {
-			dnsSecret, err := ctrl.KubeClient.CoreV1().Secrets(ctrl.crd.Namespace).Get(ctrl.crd.Spec.ChallengeProvider.DNS.CredentialSecretName, metav1.GetOptions{})
+	} else if c.challenge.DNS != nil {
+		c.ChallengeProvider = c.challenge.DNS.Provider
+		if c.challenge.DNS.CredentialSecretName != "" {
+			dnsSecret, err := c.KubeClient.CoreV1().Secrets(c.challengeNamespace).Get(c.challenge.DNS.CredentialSecretName, metav1.GetOptions{})
 			if err != nil {
-				return nil, err
+				return err
 			}
-			ctrl.DNSCredentials = dnsSecret.Data
+			c.DNSCredentials = dnsSecret.Data
 		}
+	} else {
+		return errors.Errorf("neither certificate %s/%s nor its cluster issuer has a challenge provider", c.crd.Namespace, c.crd.Name)
 	}
+	return nil
+}
 
-	ctrl.store, err = NewCertStore(kubeClient, extClient)
+// loadACMEUser reads the ACME user of the Certificate, or of its ClusterIssuer, from the user secret.
+// It returns the ClusterIssuer, if used.
+func (c *Controller) loadACMEUser() (*api.ClusterIssuer, error) {
+	var issuer *api.ClusterIssuer
+	var err error
+	userSecretNamespace, userSecretName := c.crd.Namespace, c.crd.Spec.ACMEUserSecretName
+	if name := c.crd.ClusterIssuerName(); name != "" {
+		if issuer, err = c.getClusterIssuer(name); err != nil {
+			return nil, err
+		}
+		userSecretNamespace, userSecretName = c.cfg.OperatorNamespace, issuer.Spec.ACMEUserSecretName
+	}
+	c.UserSecret, err = c.KubeClient.CoreV1().Secrets(userSecretNamespace).Get(userSecretName, metav1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
-	if ctrl.store.VaultClient == nil && ctrl.crd.Spec.Storage.Vault != nil {
-		return nil, errors.Errorf("certificate %s/%s uses vault but vault address is missing", tpr.Namespace, tpr.Name)
+	c.acmeUser = &ACMEUser{}
+
+	if email, ok := c.UserSecret.Data[api.ACMEUserEmail]; !ok {
+		return nil, errors.Errorf("no acme user email is provided")
+	} else {
+		c.acmeUser.Email = strings.TrimSpace(string(email))
 	}
 
-	return ctrl, nil
+	if u, found := c.UserSecret.Data[api.ACMEServerURL]; found {
+		c.acmeUser.ServerURL = strings.TrimSpace(string(u))
+	} else {
+		c.acmeUser.ServerURL = LetsEncryptProdURL
+	}
+	c.acmeUser.CABundle = c.UserSecret.Data[api.ACMEServerCABundle]
+
+	keyID, hasKeyID := c.UserSecret.Data[api.ACMEExternalAccountKeyID]
+	hmacKey, hasHMACKey := c.UserSecret.Data[api.ACMEExternalAccountHMACKey]
+	if hasKeyID != hasHMACKey {
+		return nil, errors.Errorf("acme user secret must contain both %s and %s for external account binding", api.ACMEExternalAccountKeyID, api.ACMEExternalAccountHMACKey)
+	}
+	c.acmeUser.ExternalAccountKeyID = strings.TrimSpace(string(keyID))
+	c.acmeUser.ExternalAccountHMACKey = strings.TrimSpace(string(hmacKey))
+	return issuer, nil
 }
 
 func (c *Controller) Process() error {
@@ -133,13 +190,45 @@ func (c *Controller) Process() error {
 		c.curCert = certs[0]
 	}
 
+	ecdsaMissing := false
+	if c.crd.Spec.DualKey {
+		ecdsaCrt, _, err := c.store.GetECDSA(c.crd)
+		if err != nil {
+			return err
+		}
+		if ecdsaCrt == nil {
+			ecdsaMissing = true
+		} else {
+			certs, err := cert.ParseCertsPEM(ecdsaCrt)
+			if err != nil {
+				return errors.Errorf("secret %s/%s contains bad ECDSA certificate. Reason: %s", c.crd.Namespace, c.crd.SecretName(), err)
+			}
+			ecdsaMissing = !c.crd.MatchesDomains(certs[0])
+		}
+	}
+
+	revoked, err := c.processRevocation(pemCrt)
+	if err != nil {
+		return err
+	}
+	if c.crd.Spec.Revoke != nil && (pemCrt == nil || (revoked && !c.crd.Spec.Revoke.Reissue)) {
+		// certificates are not issued or renewed while revocation is requested, unless reissue is set
+		return nil
+	}
+
 	// Scenario:
 	// - s1: Certificate not found
 	// - s2: Certificate found, but user run `kubectl apply` in such a way that status.LastIssuedCertificate is gone.
+	// - s3: Certificate found, but dual key Certificate is missing ECDSA certificate.
+	// - s4: Certificate found, but key algorithm or size has been changed.
+	// - s5: Certificate found, but it is revoked.
 	// ref: https://github.com/appscode/voyager/issues/744
 	if pemCrt == nil ||
+		revoked ||
 		!c.crd.MatchesDomains(c.curCert) ||
-		c.crd.Status.LastIssuedCertificate == nil {
+		!c.crd.MatchesKey(c.curCert) ||
+		c.crd.Status.LastIssuedCertificate == nil ||
+		ecdsaMissing {
 		err := c.create()
 		if err == nil {
 			c.recorder.Eventf(
@@ -164,12 +253,22 @@ func (c *Controller) Process() error {
 		}
 		return err
 	}
+
+	// spec.renewBefore may have been changed since the certificate was issued
+	next := metav1.NewTime(c.crd.RenewalTime(c.curCert)).Rfc3339Copy()
+	if !c.crd.Status.NextRenewalTime.Equal(&next) {
+		c.crd, _, err = util.PatchCertificate(c.VoyagerClient.VoyagerV1beta1(), c.crd, func(in *api.Certificate) *api.Certificate {
+			in.Status.NextRenewalTime = &next
+			return in
+		})
+		return err
+	}
 	return nil
 }
 
-func (c *Controller) getACMEClient() error {
-	var err error
-
+// loadACMEAccountKey reads the private key and registration of the ACME user from the user secret.
+// It returns false, if the user is not registered yet.
+func (c *Controller) loadACMEAccountKey() bool {
 	if data, ok := c.UserSecret.Data[api.ACMERegistrationData]; ok {
 		var reg acme.RegistrationResource
 		if err := json.Unmarshal(data, &reg); err == nil {
@@ -184,7 +283,24 @@ func (c *Controller) getACMEClient() error {
 			}
 		}
 	}
-	registered := c.acmeUser.Registration != nil && c.acmeUser.Key != nil
+	return c.acmeUser.Registration != nil && c.acmeUser.Key != nil
+}
+
+// loadACMEAccount creates the client of the registered ACME account. Unlike getACMEClient, it neither
+// registers the user nor needs the challenge provider, so it is enough to revoke certificates.
+func (c *Controller) loadACMEAccount() error {
+	if !c.loadACMEAccountKey() {
+		return errors.Errorf("acme user secret %s/%s has no registered account", c.UserSecret.Namespace, c.UserSecret.Name)
+	}
+	var err error
+	c.acmeAccount, err = newACMEAccount(c.acmeUser)
+	return err
+}
+
+func (c *Controller) getACMEClient() error {
+	var err error
+
+	registered := c.loadACMEAccountKey()
 
 	if c.acmeUser.Key == nil {
 		log.Infoln("No ACME user found, registering a new ACME user")
@@ -195,13 +311,21 @@ func (c *Controller) getACMEClient() error {
 		c.acmeUser.Key = userKey
 	}
 
-	c.acmeClient, err = c.newACMEClient()
+	c.acmeAccount, err = newACMEAccount(c.acmeUser)
 	if err != nil {
 		return err
 	}
+	if len(c.acmeUser.CABundle) > 0 {
+		if err = legoTransport.route(c.acmeUser.getServerURL(), c.acmeAccount.client); err != nil {
+			return err
+		}
+	}
 
 	if !registered {
-		registration, err := c.acmeClient.Register(true)
+		if c.acmeUser.ExternalAccountKeyID == "" && c.acmeAccount.directory.Meta.ExternalAccountRequired {
+			return errors.Errorf("acme server %s requires external account binding, set %s and %s in acme user secret", c.acmeUser.ServerURL, api.ACMEExternalAccountKeyID, api.ACMEExternalAccountHMACKey)
+		}
+		registration, err := c.acmeAccount.register(c.acmeUser.Email, c.acmeUser.ExternalAccountKeyID, c.acmeUser.ExternalAccountHMACKey)
 		if err != nil {
 			return errors.Errorf("failed to register user %s. Reason: %s", c.acmeUser.Email, err)
 		}
@@ -215,48 +339,102 @@ func (c *Controller) getACMEClient() error {
 			in.Data[api.ACMERegistrationData] = regBytes
 			return in
 		})
-		return err
+		if err != nil {
+			return err
+		}
 	}
-	return nil
+
+	// lego uses the account url of registration, so the client is created once registered
+	c.acmeClient, err = c.newACMEClient()
+	return err
 }
 
 func (c *Controller) create() error {
-	if err := c.getACMEClient(); err != nil {
-		return err
+	cert, err := c.issuer.Issue(c.crd.Spec.Domains, nil)
+	if err != nil {
+		return c.processError(errors.Wrap(err, "failed to create certificate."))
 	}
-	if c.ChallengeProvider == "http" {
-		if err := c.updateIngress(); err != nil {
-			return err
+	ecdsaCert, err := c.obtainECDSACertificate(false)
+	if err != nil {
+		return c.processError(errors.Wrap(err, "failed to create ECDSA certificate."))
+	}
+	return c.save(cert, ecdsaCert)
+}
+
+// obtainECDSACertificate issues the ECDSA certificate of a dual key Certificate. If reuseKey is set,
+// the existing ECDSA private key is used if found, otherwise a new private key is generated.
+// It returns nil for other Certificates.
+func (c *Controller) obtainECDSACertificate(reuseKey bool) (*acme.CertificateResource, error) {
+	if !c.crd.Spec.DualKey {
+		return nil, nil
+	}
+	var key crypto.PrivateKey
+	if reuseKey {
+		_, pemKey, err := c.store.GetECDSA(c.crd)
+		if err != nil {
+			return nil, err
+		}
+		if pemKey != nil {
+			if k, err := cert.ParsePrivateKeyPEM(pemKey); err == nil {
+				if ecKey, ok := k.(*ecdsa.PrivateKey); ok {
+					key = ecKey
+				}
+			}
+		}
+	}
+	if key == nil {
+		var err error
+		if key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+			return nil, errors.Wrap(err, "failed to generate ECDSA key")
 		}
 	}
-	cert, err := c.acmeClient.ObtainCertificate(c.crd.Spec.Domains, true, nil, false)
+	cert, err := c.issuer.Issue(c.crd.Spec.Domains, key)
 	if err != nil {
-		return c.processError(errors.Wrap(err, "failed to create certificate."))
+		return nil, err
 	}
-	return c.store.Save(c.crd, cert)
+	return &cert, nil
 }
 
 func (c *Controller) renew() error {
-	if err := c.getACMEClient(); err != nil {
-		return err
-	}
-	if c.ChallengeProvider == "http" {
-		if err := c.updateIngress(); err != nil {
+	var key crypto.PrivateKey // nil issues new private key
+	if c.crd.Spec.ReuseKey {
+		_, pemKey, err := c.store.Get(c.crd)
+		if err != nil {
 			return err
 		}
+		if key, err = cert.ParsePrivateKeyPEM(pemKey); err != nil {
+			return errors.Errorf("secret %s/%s contains bad private key. Reason: %s", c.crd.Namespace, c.crd.SecretName(), err)
+		}
 	}
-	acmeCert := acme.CertificateResource{
-		CertURL:       c.crd.Status.LastIssuedCertificate.CertURL,
-		CertStableURL: c.crd.Status.LastIssuedCertificate.CertStableURL,
-		AccountRef:    c.crd.Status.LastIssuedCertificate.AccountRef,
-		Certificate:   cert.EncodeCertPEM(c.curCert),
-		PrivateKey:    nil, // issue new private key,
-	}
-	cert, err := c.acmeClient.RenewCertificate(acmeCert, true, false)
+	cert, err := c.issuer.Issue(c.crd.Spec.Domains, key)
 	if err != nil {
 		return c.processError(err)
 	}
-	return c.store.Save(c.crd, cert)
+	ecdsaCert, err := c.obtainECDSACertificate(c.crd.Spec.ReuseKey)
+	if err != nil {
+		return c.processError(errors.Wrap(err, "failed to renew ECDSA certificate."))
+	}
+	return c.save(cert, ecdsaCert)
+}
+
+// save stores the issued certificates and makes the issued certificate current.
+func (c *Controller) save(crt acme.CertificateResource, ecdsaCert *acme.CertificateResource) error {
+	if err := c.store.Save(c.crd, crt, ecdsaCert, c.acmeUser); err != nil {
+		return err
+	}
+	if certs, err := cert.ParseCertsPEM(crt.Certificate); err == nil {
+		c.curCert = certs[0]
+	}
+	return nil
+}
+
+// NextRenewalTime returns when the current certificate should be renewed. It must be called after Process succeeds.
+// It returns false if no certificate is stored, ie, a revoked certificate was deleted and is not reissued.
+func (c *Controller) NextRenewalTime() (time.Time, bool) {
+	if c.curCert == nil {
+		return time.Time{}, false
+	}
+	return c.crd.RenewalTime(c.curCert), true
 }
 
 func (c *Controller) processError(err error) error {
@@ -286,94 +464,3 @@ func (c *Controller) processError(err error) error {
 	})
 	return err
 }
-
-func (c *Controller) updateIngress() error {
-	switch c.crd.Spec.ChallengeProvider.HTTP.Ingress.APIVersion {
-	case api.SchemeGroupVersion.String():
-		i, err := c.VoyagerClient.VoyagerV1beta1().
-			Ingresses(c.crd.Namespace).
-			Get(c.crd.Spec.ChallengeProvider.HTTP.Ingress.Name, metav1.GetOptions{})
-		if err != nil {
-			return errors.WithStack(err)
-		}
-		for _, rules := range i.Spec.Rules {
-			if rules.HTTP != nil {
-				for _, path := range rules.HTTP.Paths {
-					if path.Path == providers.URLPrefix {
-						// Rule found no update required
-						return nil
-					}
-				}
-			}
-		}
-
-		rule := api.IngressRule{
-			IngressRuleValue: api.IngressRuleValue{
-				HTTP: &api.HTTPIngressRuleValue{
-					NoTLS: true,
-					Paths: []api.HTTPIngressPath{
-						{
-							Path: providers.URLPrefix,
-							Backend: api.HTTPIngressBackend{
-								IngressBackend: api.IngressBackend{
-									ServiceName: c.cfg.OperatorService + "." + c.cfg.OperatorNamespace,
-									ServicePort: intstr.FromInt(providers.ACMEResponderPort),
-								},
-							},
-						},
-					},
-				},
-			},
-		}
-		i.Spec.Rules = append([]api.IngressRule{rule}, i.Spec.Rules...)
-
-		_, err = c.VoyagerClient.VoyagerV1beta1().Ingresses(c.crd.Namespace).Update(i)
-		if err != nil {
-			return errors.WithStack(err)
-		}
-		time.Sleep(time.Second * 5)
-	case "extensions/v1beta1":
-		i, err := c.KubeClient.ExtensionsV1beta1().Ingresses(c.crd.Namespace).
-			Get(c.crd.Spec.ChallengeProvider.HTTP.Ingress.Name, metav1.GetOptions{})
-		if err != nil {
-			return errors.WithStack(err)
-		}
-
-		for _, rules := range i.Spec.Rules {
-			if rules.HTTP != nil {
-				for _, path := range rules.HTTP.Paths {
-					if path.Path == providers.URLPrefix {
-						// Rule found no update required
-						return nil
-					}
-				}
-			}
-		}
-
-		rule := extensions.IngressRule{
-			IngressRuleValue: extensions.IngressRuleValue{
-				HTTP: &extensions.HTTPIngressRuleValue{
-					Paths: []extensions.HTTPIngressPath{
-						{
-							Path: providers.URLPrefix,
-							Backend: extensions.IngressBackend{
-								ServiceName: c.cfg.OperatorService + "." + c.cfg.OperatorNamespace,
-								ServicePort: intstr.FromInt(providers.ACMEResponderPort),
-							},
-						},
-					},
-				},
-			},
-		}
-		i.Spec.Rules = append([]extensions.IngressRule{rule}, i.Spec.Rules...)
-
-		_, err = c.KubeClient.ExtensionsV1beta1().Ingresses(c.crd.Namespace).Update(i)
-		if err != nil {
-			return errors.WithStack(err)
-		}
-		time.Sleep(time.Second * 5)
-	default:
-		return errors.New("HTTP Certificate resolver do not have any ingress reference or invalid ingress reference")
-	}
-	return nil
-}
@@ -0,0 +1,156 @@
+package certificate
+
+import (
+	"time"
+
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	cs "github.com/appscode/voyager/client/clientset/versioned"
+	"github.com/appscode/voyager/pkg/config"
+	"github.com/appscode/voyager/pkg/eventer"
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/cert"
+)
+
+// Delete cleans up a deleted Certificate as per spec.deletionPolicy. For RevokeAndDelete, the current certificate
+// is revoked at the ACME server before its storage is deleted. The ACME user secret is deleted too, if no other
+// Certificate or Ingress in the namespace uses it.
+func Delete(kubeClient kubernetes.Interface, extClient cs.Interface, cfg config.Config, crd *api.Certificate) error {
+	if crd.Spec.DeletionPolicy != api.CertificateDeletionPolicyDelete &&
+		crd.Spec.DeletionPolicy != api.CertificateDeletionPolicyRevokeAndDelete {
+		return nil
+	}
+	store, err := NewCertStore(kubeClient, extClient)
+	if err != nil {
+		return err
+	}
+	if crd.Spec.DeletionPolicy == api.CertificateDeletionPolicyRevokeAndDelete {
+		// only the ACME account is used, the challenge provider of a deleted Certificate may be gone
+		ctrl := &Controller{
+			KubeClient:    kubeClient,
+			VoyagerClient: extClient,
+			cfg:           cfg,
+			crd:           crd,
+			recorder:      eventer.NewEventRecorder(kubeClient, "voyager-operator"),
+			store:         store,
+		}
+		if err = ctrl.revokeOnDeletion(); err != nil {
+			return err
+		}
+	}
+
+	if err = store.Delete(crd); err != nil {
+		return errors.Wrapf(err, "failed to delete stored certificate of %s/%s", crd.Namespace, crd.Name)
+	}
+	if crd.UsesACME() && crd.ClusterIssuerName() == "" {
+		return deleteACMEUserSecret(kubeClient, extClient, crd)
+	}
+	return nil
+}
+
+// revokeOnDeletion revokes the current certificate of a Certificate being deleted. Certificates that are
+// expired or already revoked for spec.revoke are skipped. Only the ACME account is needed, so the challenge
+// provider may be gone. If the ACME user secret or ClusterIssuer is gone too, revocation is skipped with an event.
+func (c *Controller) revokeOnDeletion() error {
+	pemCrt, _, err := c.store.Get(c.crd)
+	if err != nil || pemCrt == nil {
+		return err
+	}
+	certs, err := cert.ParseCertsPEM(pemCrt)
+	if err != nil {
+		return errors.Errorf("secret %s/%s contains bad certificate. Reason: %s", c.crd.Namespace, c.crd.SecretName(), err)
+	}
+	c.curCert = certs[0]
+	if isRevoked(c.crd, c.curCert) || time.Now().After(c.curCert.NotAfter) {
+		return nil
+	}
+	if _, err = c.loadACMEUser(); err != nil {
+		if !k8serror.IsNotFound(errors.Cause(err)) {
+			return err
+		}
+		c.recorder.Eventf(
+			c.crd.ObjectReference(),
+			core.EventTypeWarning,
+			eventer.EventReasonCertificateRevokeFailed,
+			"Skipped revocation of certificate %s. Reason: %s",
+			c.curCert.SerialNumber,
+			err,
+		)
+		return nil
+	}
+
+	if err = c.revokeAtServer(pemCrt, revocationReasonCodes[api.RevocationReasonCessationOfOperation]); err != nil {
+		c.recorder.Event(
+			c.crd.ObjectReference(),
+			core.EventTypeWarning,
+			eventer.EventReasonCertificateRevokeFailed,
+			err.Error(),
+		)
+		return err
+	}
+	c.recorder.Eventf(
+		c.crd.ObjectReference(),
+		core.EventTypeNormal,
+		eventer.EventReasonCertificateRevokeSuccessful,
+		"Successfully revoked certificate %s. Reason: %s",
+		c.curCert.SerialNumber,
+		api.RevocationReasonCessationOfOperation,
+	)
+	return nil
+}
+
+// deleteACMEUserSecret deletes the ACME user secret of a deleted Certificate, unless another Certificate
+// or the acme-issuer annotation of an Ingress in its namespace still refers to it.
+func deleteACMEUserSecret(kubeClient kubernetes.Interface, extClient cs.Interface, crd *api.Certificate) error {
+	name := crd.Spec.ACMEUserSecretName
+	if name == "" {
+		return nil
+	}
+	inUse, err := isACMEUserSecretInUse(kubeClient, extClient, crd)
+	if err != nil || inUse {
+		return err
+	}
+	err = kubeClient.CoreV1().Secrets(crd.Namespace).Delete(name, &metav1.DeleteOptions{})
+	if err != nil && !k8serror.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete acme user secret %s/%s", crd.Namespace, name)
+	}
+	return nil
+}
+
+func isACMEUserSecretInUse(kubeClient kubernetes.Interface, extClient cs.Interface, crd *api.Certificate) (bool, error) {
+	name := crd.Spec.ACMEUserSecretName
+
+	certs, err := extClient.VoyagerV1beta1().Certificates(crd.Namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return false, err
+	}
+	for _, c := range certs.Items {
+		if c.Name != crd.Name && c.Spec.ACMEUserSecretName == name {
+			return true, nil
+		}
+	}
+
+	engresses, err := extClient.VoyagerV1beta1().Ingresses(crd.Namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return false, err
+	}
+	for _, ing := range engresses.Items {
+		if ing.Annotations[api.ACMEIssuerSecret] == name {
+			return true, nil
+		}
+	}
+
+	ingresses, err := kubeClient.ExtensionsV1beta1().Ingresses(crd.Namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return false, err
+	}
+	for _, ing := range ingresses.Items {
+		if ing.Annotations[api.ACMEIssuerSecret] == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,127 @@
+package certificate
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	vfake "github.com/appscode/voyager/client/clientset/versioned/fake"
+	"github.com/appscode/voyager/pkg/config"
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/util/cert"
+)
+
+func TestDelete(t *testing.T) {
+	newCert := func(name string, policy api.CertificateDeletionPolicy) *api.Certificate {
+		return &api.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: api.CertificateSpec{
+				Domains:            []string{name + ".example.com"},
+				ACMEUserSecretName: "acme-account",
+				DeletionPolicy:     policy,
+			},
+		}
+	}
+	first, second := newCert("first", api.CertificateDeletionPolicyDelete), newCert("second", api.CertificateDeletionPolicyRetain)
+
+	kubeClient := fake.NewSimpleClientset(
+		&core.Secret{ObjectMeta: metav1.ObjectMeta{Name: first.SecretName(), Namespace: "default"}},
+		&core.Secret{ObjectMeta: metav1.ObjectMeta{Name: second.SecretName(), Namespace: "default"}},
+		&core.Secret{ObjectMeta: metav1.ObjectMeta{Name: "acme-account", Namespace: "default"}},
+	)
+	extClient := vfake.NewSimpleClientset(first, second)
+	secretExists := func(name string) bool {
+		_, err := kubeClient.CoreV1().Secrets("default").Get(name, metav1.GetOptions{})
+		return err == nil
+	}
+
+	// retained
+	assert.NoError(t, Delete(kubeClient, extClient, config.Config{}, second))
+	assert.True(t, secretExists(second.SecretName()))
+
+	// acme user secret is still used by second
+	assert.NoError(t, Delete(kubeClient, extClient, config.Config{}, first))
+	assert.False(t, secretExists(first.SecretName()))
+	assert.True(t, secretExists("acme-account"))
+
+	// deleting again is not an error
+	assert.NoError(t, Delete(kubeClient, extClient, config.Config{}, first))
+
+	assert.NoError(t, extClient.VoyagerV1beta1().Certificates("default").Delete(second.Name, &metav1.DeleteOptions{}))
+	assert.NoError(t, Delete(kubeClient, extClient, config.Config{}, first))
+	assert.False(t, secretExists("acme-account"))
+}
+
+func TestDeleteRevokeAndDelete(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var server *httptest.Server
+	revoked := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/directory", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"newNonce":"%[1]s/nonce","newAccount":"%[1]s/account","revokeCert":"%[1]s/revoke"}`, server.URL)
+	})
+	mux.HandleFunc("/nonce", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Replay-Nonce", "nonce-1")
+	})
+	mux.HandleFunc("/revoke", func(w http.ResponseWriter, r *http.Request) {
+		revoked++
+	})
+	server = httptest.NewServer(mux)
+	defer server.Close()
+
+	// the http challenge ingress does not exist
+	crd := &api.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: "revoked", Namespace: "default"},
+		Spec: api.CertificateSpec{
+			Domains:            []string{"example.com"},
+			ACMEUserSecretName: "acme-account",
+			ChallengeProvider: api.ChallengeProvider{HTTP: &api.HTTPChallengeProvider{
+				Ingress: api.LocalTypedReference{APIVersion: api.SchemeGroupVersion.String(), Kind: "Ingress", Name: "gone"},
+			}},
+			DeletionPolicy: api.CertificateDeletionPolicyRevokeAndDelete,
+		},
+	}
+	stored := func() *core.Secret {
+		return &core.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: crd.SecretName(), Namespace: "default"},
+			Data: map[string][]byte{
+				core.TLSCertKey:       newTestChain(t, "Root"),
+				core.TLSPrivateKeyKey: []byte("key"),
+			},
+		}
+	}
+	kubeClient := fake.NewSimpleClientset(stored(), &core.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "acme-account", Namespace: "default"},
+		Data: map[string][]byte{
+			api.ACMEUserEmail:        []byte("user@example.com"),
+			api.ACMEServerURL:        []byte(server.URL + "/directory"),
+			api.ACMEUserPrivatekey:   cert.EncodePrivateKeyPEM(key),
+			api.ACMERegistrationData: []byte(`{"uri":"` + server.URL + `/account/1"}`),
+		},
+	})
+	extClient := vfake.NewSimpleClientset(crd)
+
+	assert.NoError(t, Delete(kubeClient, extClient, config.Config{}, crd))
+	assert.Equal(t, 1, revoked)
+	_, err = kubeClient.CoreV1().Secrets("default").Get(crd.SecretName(), metav1.GetOptions{})
+	assert.True(t, k8serror.IsNotFound(err))
+
+	// acme user secret was deleted along with the Certificate, so revocation is skipped
+	_, err = kubeClient.CoreV1().Secrets("default").Create(stored())
+	assert.NoError(t, err)
+	assert.NoError(t, Delete(kubeClient, extClient, config.Config{}, crd))
+	assert.Equal(t, 1, revoked)
+	_, err = kubeClient.CoreV1().Secrets("default").Get(crd.SecretName(), metav1.GetOptions{})
+	assert.True(t, k8serror.IsNotFound(err))
+}
@@ -0,0 +1,271 @@
+package certificate
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math"
+	"math/big"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/appscode/go/log"
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	vault "github.com/hashicorp/vault/api"
+	"github.com/pkg/errors"
+	"github.com/xenolf/lego/acmev2"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/cert"
+)
+
+// Issuer signs certificates for the domains of a Certificate.
+type Issuer interface {
+	// Issue signs a certificate for domains. If key is nil, a new private key is generated.
+	Issue(domains []string, key crypto.PrivateKey) (acme.CertificateResource, error)
+}
+
+func (c *Controller) newIssuer() Issuer {
+	switch {
+	case c.crd.Spec.Issuer != nil && c.crd.Spec.Issuer.SelfSigned != nil:
+		return &selfSignedIssuer{crd: c.crd}
+	case c.crd.Spec.Issuer != nil && c.crd.Spec.Issuer.CA != nil:
+		return &caIssuer{ctrl: c}
+	case c.crd.Spec.Issuer != nil && c.crd.Spec.Issuer.Vault != nil:
+		return &vaultIssuer{client: c.store.VaultClient, crd: c.crd}
+	}
+	return &acmeIssuer{ctrl: c}
+}
+
+type acmeIssuer struct {
+	ctrl *Controller
+}
+
+var _ Issuer = &acmeIssuer{}
+
+func (i *acmeIssuer) Issue(domains []string, key crypto.PrivateKey) (acme.CertificateResource, error) {
+	if i.ctrl.acmeClient == nil {
+		if err := i.ctrl.getACMEClient(); err != nil {
+			return acme.CertificateResource{}, err
+		}
+	}
+	res, err := i.ctrl.acmeClient.ObtainCertificate(domains, true, key, false)
+	if err != nil {
+		return res, err
+	}
+	if issuer := i.ctrl.crd.Spec.Issuer; issuer != nil && issuer.ACME != nil && issuer.ACME.PreferredChain != "" {
+		chain, err := i.ctrl.acmeAccount.preferredChain(res.CertURL, res.Certificate, issuer.ACME.PreferredChain)
+		if err != nil {
+			// the default chain is valid too
+			log.Warningf("failed to get preferred chain of certificate %s/%s. Reason: %v", i.ctrl.crd.Namespace, i.ctrl.crd.Name, err)
+		} else {
+			res.Certificate = chain
+		}
+	}
+	return res, nil
+}
+
+type selfSignedIssuer struct {
+	crd *api.Certificate
+}
+
+var _ Issuer = &selfSignedIssuer{}
+
+func (i *selfSignedIssuer) Issue(domains []string, key crypto.PrivateKey) (acme.CertificateResource, error) {
+	return signCertificate(i.crd, domains, key, nil, nil)
+}
+
+type caIssuer struct {
+	ctrl *Controller
+}
+
+var _ Issuer = &caIssuer{}
+
+func (i *caIssuer) Issue(domains []string, key crypto.PrivateKey) (acme.CertificateResource, error) {
+	crd := i.ctrl.crd
+	secret, err := i.ctrl.KubeClient.CoreV1().Secrets(crd.Namespace).Get(crd.Spec.Issuer.CA.SecretName, metav1.GetOptions{})
+	if err != nil {
+		return acme.CertificateResource{}, err
+	}
+	caCerts, err := cert.ParseCertsPEM(secret.Data[core.TLSCertKey])
+	if err != nil {
+		return acme.CertificateResource{}, errors.Errorf("secret %s/%s contains bad CA certificate. Reason: %s", crd.Namespace, secret.Name, err)
+	}
+	caKey, err := cert.ParsePrivateKeyPEM(secret.Data[core.TLSPrivateKeyKey])
+	if err != nil {
+		return acme.CertificateResource{}, errors.Errorf("secret %s/%s contains bad CA private key. Reason: %s", crd.Namespace, secret.Name, err)
+	}
+	return signCertificate(crd, domains, key, caCerts[0], caKey)
+}
+
+type vaultIssuer struct {
+	client *vault.Client
+	crd    *api.Certificate
+}
+
+var _ Issuer = &vaultIssuer{}
+
+// Issue sends a CSR signed by key to pki/sign/<role>. If key is nil, a new private key is generated
+// as specified by the Certificate, so that a certificate is never issued by Vault with a private key
+// that does not match it, ie, as pki/issue/<role> would do with the key settings of the role.
+// ref: https://www.vaultproject.io/api/secret/pki/index.html#sign-certificate
+func (i *vaultIssuer) Issue(domains []string, key crypto.PrivateKey) (acme.CertificateResource, error) {
+	mount := i.crd.Spec.Issuer.Vault.Path
+	if mount == "" {
+		mount = "pki"
+	}
+	var err error
+	if key == nil {
+		if key, err = newPrivateKey(i.crd.KeyParameters()); err != nil {
+			return acme.CertificateResource{}, err
+		}
+	}
+	csr, err := newCSR(domains, key)
+	if err != nil {
+		return acme.CertificateResource{}, err
+	}
+	data := map[string]interface{}{
+		"csr":         string(csr),
+		"common_name": domains[0],
+		"alt_names":   strings.Join(domains[1:], ","),
+		"format":      "pem",
+	}
+	if i.crd.Spec.Duration != nil {
+		data["ttl"] = i.crd.Spec.Duration.Duration.String()
+	}
+
+	secret, err := i.client.Logical().Write(path.Join(mount, "sign", i.crd.Spec.Issuer.Vault.Role), data)
+	if err != nil {
+		return acme.CertificateResource{}, errors.Wrap(err, "failed to sign certificate from vault")
+	}
+	if secret == nil {
+		return acme.CertificateResource{}, errors.Errorf("vault returned no certificate from %s", path.Join(mount, "sign"))
+	}
+	pemCrt, _ := secret.Data["certificate"].(string)
+	certs, err := cert.ParseCertsPEM([]byte(pemCrt))
+	if err != nil {
+		return acme.CertificateResource{}, errors.Wrap(err, "vault returned bad certificate")
+	}
+	pemKey, err := encodePrivateKeyPEM(key)
+	if err != nil {
+		return acme.CertificateResource{}, err
+	}
+
+	bundle := cert.EncodeCertPEM(certs[0])
+	if chain, ok := secret.Data["ca_chain"].([]interface{}); ok && len(chain) > 0 {
+		for _, ca := range chain {
+			if str, ok := ca.(string); ok {
+				bundle = append(bundle, []byte(strings.TrimSpace(str)+"\n")...)
+			}
+		}
+	} else if str, ok := secret.Data["issuing_ca"].(string); ok {
+		bundle = append(bundle, []byte(strings.TrimSpace(str)+"\n")...)
+	}
+	return acme.CertificateResource{
+		Domain:      domains[0],
+		Certificate: bundle,
+		PrivateKey:  pemKey,
+	}, nil
+}
+
+func newCSR(domains []string, key crypto.PrivateKey) ([]byte, error) {
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: domains[0]},
+		DNSNames: domains,
+	}, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create CSR")
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}), nil
+}
+
+// signCertificate signs a certificate for domains with caKey. If caCert is nil,
+// the certificate is self-signed with its own private key.
+func signCertificate(crd *api.Certificate, domains []string, key crypto.PrivateKey, caCert *x509.Certificate, caKey crypto.PrivateKey) (acme.CertificateResource, error) {
+	var err error
+	if key == nil {
+		if key, err = newPrivateKey(crd.KeyParameters()); err != nil {
+			return acme.CertificateResource{}, err
+		}
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return acme.CertificateResource{}, errors.New("unsupported private key type")
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
+	if err != nil {
+		return acme.CertificateResource{}, err
+	}
+
+	now := time.Now()
+	tmpl := x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: domains[0]},
+		DNSNames:              domains,
+		NotBefore:             now.Add(-5 * time.Minute).UTC(),
+		NotAfter:              now.Add(crd.CertificateDuration()).UTC(),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	parent, parentKey := &tmpl, crypto.PrivateKey(signer)
+	if caCert != nil {
+		if tmpl.NotAfter.After(caCert.NotAfter) {
+			tmpl.NotAfter = caCert.NotAfter
+		}
+		parent, parentKey = caCert, caKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, parent, signer.Public(), parentKey)
+	if err != nil {
+		return acme.CertificateResource{}, errors.Wrap(err, "failed to sign certificate")
+	}
+	crt, err := x509.ParseCertificate(der)
+	if err != nil {
+		return acme.CertificateResource{}, err
+	}
+	pemKey, err := encodePrivateKeyPEM(key)
+	if err != nil {
+		return acme.CertificateResource{}, err
+	}
+
+	bundle := cert.EncodeCertPEM(crt)
+	if caCert != nil {
+		bundle = append(bundle, cert.EncodeCertPEM(caCert)...)
+	}
+	return acme.CertificateResource{
+		Domain:      domains[0],
+		Certificate: bundle,
+		PrivateKey:  pemKey,
+	}, nil
+}
+
+func newPrivateKey(alg api.KeyAlgorithm, size int) (crypto.PrivateKey, error) {
+	if alg == api.KeyAlgorithmECDSA {
+		curve := elliptic.P256()
+		if size == 384 {
+			curve = elliptic.P384()
+		}
+		return ecdsa.GenerateKey(curve, rand.Reader)
+	}
+	return rsa.GenerateKey(rand.Reader, size)
+}
+
+func encodePrivateKeyPEM(key crypto.PrivateKey) ([]byte, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return cert.EncodePrivateKeyPEM(k), nil
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			return nil, err
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: cert.ECPrivateKeyBlockType, Bytes: der}), nil
+	}
+	return nil, errors.New("unsupported private key type")
+}
@@ -0,0 +1,139 @@
+package certificate
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	vault "github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/cert"
+)
+
+func TestSignCertificate(t *testing.T) {
+	crd := &api.Certificate{
+		Spec: api.CertificateSpec{
+			Domains:      []string{"example.com", "www.example.com"},
+			KeyAlgorithm: api.KeyAlgorithmECDSA,
+			Duration:     &metav1.Duration{Duration: 24 * time.Hour},
+		},
+	}
+
+	// self-signed
+	res, err := signCertificate(crd, crd.Spec.Domains, nil, nil, nil)
+	if assert.NoError(t, err) {
+		certs, err := cert.ParseCertsPEM(res.Certificate)
+		if assert.NoError(t, err) && assert.Len(t, certs, 1) {
+			assert.Equal(t, crd.Spec.Domains, certs[0].DNSNames)
+			assert.Equal(t, x509.ECDSA, certs[0].PublicKeyAlgorithm)
+			assert.True(t, crd.MatchesKey(certs[0]))
+			assert.WithinDuration(t, time.Now().Add(24*time.Hour), certs[0].NotAfter, time.Minute)
+			assert.NoError(t, certs[0].CheckSignature(certs[0].SignatureAlgorithm, certs[0].RawTBSCertificate, certs[0].Signature))
+		}
+		_, err = cert.ParsePrivateKeyPEM(res.PrivateKey)
+		assert.NoError(t, err)
+	}
+
+	// signed by CA, lifetime capped by the CA certificate
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, err := cert.NewSelfSignedCACert(cert.Config{CommonName: "ca"}, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	crd.Spec.Duration = &metav1.Duration{Duration: 20 * 365 * 24 * time.Hour}
+	res, err = signCertificate(crd, crd.Spec.Domains, nil, caCert, caKey)
+	if assert.NoError(t, err) {
+		certs, err := cert.ParseCertsPEM(res.Certificate)
+		if assert.NoError(t, err) && assert.Len(t, certs, 2) {
+			assert.NoError(t, certs[0].CheckSignatureFrom(caCert))
+			assert.True(t, certs[0].NotAfter.Equal(caCert.NotAfter))
+		}
+	}
+}
+
+// TestVaultIssuer runs against a Vault dev server, ie,
+// `vault server -dev` with VAULT_ADDR and VAULT_TOKEN set to its address and root token.
+func TestVaultIssuer(t *testing.T) {
+	if os.Getenv(vault.EnvVaultAddress) == "" {
+		t.Skip("VAULT_ADDR is not set")
+	}
+	client, err := vault.NewClient(vault.DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mount := fmt.Sprintf("voyager-test-pki-%d", time.Now().UnixNano())
+	if err := client.Sys().Mount(mount, &vault.MountInput{Type: "pki"}); err != nil {
+		t.Fatal(err)
+	}
+	defer client.Sys().Unmount(mount)
+	if _, err := client.Logical().Write(mount+"/root/generate/internal", map[string]interface{}{
+		"common_name": "voyager test ca",
+		"ttl":         "48h",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Logical().Write(mount+"/roles/test", map[string]interface{}{
+		"allowed_domains":  "example.com",
+		"allow_subdomains": true,
+		"key_type":         "rsa",
+		"key_bits":         2048,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	crd := &api.Certificate{
+		Spec: api.CertificateSpec{
+			Domains:  []string{"app.example.com", "www.example.com"},
+			Issuer:   &api.CertificateIssuer{Vault: &api.VaultIssuer{Path: mount, Role: "test"}},
+			Duration: &metav1.Duration{Duration: time.Hour},
+			ReuseKey: true,
+		},
+	}
+	issuer := &vaultIssuer{client: client, crd: crd}
+
+	res, err := issuer.Issue(crd.Spec.Domains, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	certs, err := cert.ParseCertsPEM(res.Certificate)
+	if assert.NoError(t, err) && assert.Len(t, certs, 2) {
+		assert.Equal(t, crd.Spec.Domains, certs[0].DNSNames)
+		assert.True(t, crd.MatchesKey(certs[0]))
+		assert.NoError(t, certs[0].CheckSignatureFrom(certs[1]))
+		assert.WithinDuration(t, time.Now().Add(time.Hour), certs[0].NotAfter, time.Minute)
+	}
+
+	// renew with the same private key
+	key, err := cert.ParsePrivateKeyPEM(res.PrivateKey)
+	if !assert.NoError(t, err) {
+		return
+	}
+	renewed, err := issuer.Issue(crd.Spec.Domains, key)
+	if assert.NoError(t, err) {
+		renewedCerts, err := cert.ParseCertsPEM(renewed.Certificate)
+		if assert.NoError(t, err) {
+			assert.NotEqual(t, certs[0].SerialNumber, renewedCerts[0].SerialNumber)
+			assert.True(t, reflect.DeepEqual(certs[0].PublicKey, renewedCerts[0].PublicKey))
+		}
+		renewedKey, err := cert.ParsePrivateKeyPEM(renewed.PrivateKey)
+		if assert.NoError(t, err) {
+			assert.True(t, reflect.DeepEqual(key, renewedKey))
+		}
+	}
+
+	// key settings of the Certificate must be allowed by the role
+	crd.Spec.KeyAlgorithm = api.KeyAlgorithmECDSA
+	_, err = issuer.Issue(crd.Spec.Domains, nil)
+	assert.Error(t, err)
+}
@@ -5,8 +5,17 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/appscode/go/log"
+	core_util "github.com/appscode/kutil/core/v1"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	core_informers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
 )
 
 const (
@@ -31,6 +40,11 @@ type HTTPProviderServer struct {
 	ChallengeHolders map[string]string
 	mu               sync.Mutex
 	once             sync.Once
+
+	// ConfigMap shared by replicas of the operator, set via Share
+	client   kubernetes.Interface
+	shared   metav1.ObjectMeta
+	informer cache.SharedIndexInformer
 }
 
 // NewHTTPProviderServer creates a new HTTPProviderServer on the selected interface and port.
@@ -45,17 +59,90 @@ func NewHTTPProviderServer() *HTTPProviderServer {
 // Present starts a web server and makes the token available at `HTTP01ChallengePath(token)` for web requests.
 func (s *HTTPProviderServer) Present(domain, token, keyAuth string) error {
 	s.mu.Lock()
-	defer s.mu.Unlock()
 	s.ChallengeHolders[token+"@"+domain] = keyAuth
-	return nil
+	s.mu.Unlock()
+
+	return s.updateShared(func(in *core.ConfigMap) *core.ConfigMap {
+		if in.Data == nil {
+			in.Data = map[string]string{}
+		}
+		in.Data[sharedKey(domain, token)] = keyAuth
+		return in
+	})
 }
 
 // CleanUp closes the HTTP server and removes the token from `HTTP01ChallengePath(token)`
 func (s *HTTPProviderServer) CleanUp(domain, token, keyAuth string) error {
 	s.mu.Lock()
-	defer s.mu.Unlock()
 	delete(s.ChallengeHolders, token+"@"+domain)
-	return nil
+	s.mu.Unlock()
+
+	return s.updateShared(func(in *core.ConfigMap) *core.ConfigMap {
+		delete(in.Data, sharedKey(domain, token))
+		return in
+	})
+}
+
+// Share keeps challenges in the given ConfigMap too, and serves the challenges found there. Requests for
+// challenges are sent to any replica of the operator, while only the leader presents challenges.
+// The ConfigMap is watched until stopCh is closed.
+func (s *HTTPProviderServer) Share(client kubernetes.Interface, namespace, name string, stopCh <-chan struct{}) {
+	informer := core_informers.NewFilteredConfigMapInformer(client, namespace, 10*time.Minute, cache.Indexers{}, func(options *metav1.ListOptions) {
+		options.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+	})
+
+	s.mu.Lock()
+	s.client = client
+	s.shared = metav1.ObjectMeta{Namespace: namespace, Name: name}
+	s.informer = informer
+	s.mu.Unlock()
+
+	go informer.Run(stopCh)
+}
+
+func (s *HTTPProviderServer) updateShared(transform func(*core.ConfigMap) *core.ConfigMap) error {
+	s.mu.Lock()
+	client, meta := s.client, s.shared
+	s.mu.Unlock()
+
+	if client == nil {
+		return nil
+	}
+	_, err := client.CoreV1().ConfigMaps(meta.Namespace).Get(meta.Name, metav1.GetOptions{})
+	if kerr.IsNotFound(err) {
+		_, err = client.CoreV1().ConfigMaps(meta.Namespace).Create(transform(&core.ConfigMap{ObjectMeta: meta}))
+		return err
+	} else if err != nil {
+		return err
+	}
+	// challenges of concurrent orders are presented in parallel, so update with retry on conflict
+	_, err = core_util.TryUpdateConfigMap(client, meta, transform)
+	return err
+}
+
+// keyAuth returns the key authorization of a challenge presented by this or another replica.
+func (s *HTTPProviderServer) keyAuth(domain, token string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if keyAuth, ok := s.ChallengeHolders[token+"@"+domain]; ok {
+		return keyAuth, true
+	}
+	if s.informer == nil {
+		return "", false
+	}
+	obj, exists, err := s.informer.GetStore().GetByKey(s.shared.Namespace + "/" + s.shared.Name)
+	if err != nil || !exists {
+		return "", false
+	}
+	keyAuth, ok := obj.(*core.ConfigMap).Data[sharedKey(domain, token)]
+	return keyAuth, ok
+}
+
+// sharedKey returns the key of a challenge in the shared ConfigMap. Tokens are base64url encoded,
+// so the key is a valid ConfigMap key for any domain.
+func sharedKey(domain, token string) string {
+	return token + "." + domain
 }
 
 func (s *HTTPProviderServer) serve() {
@@ -74,9 +161,7 @@ func (s *HTTPProviderServer) serve() {
 					token = token[idx+len(URLPrefix):]
 				}
 
-				s.mu.Lock()
-				defer s.mu.Unlock()
-				keyAuth, ok := s.ChallengeHolders[token+"@"+r.Host]
+				keyAuth, ok := s.keyAuth(r.Host, token)
 
 				if ok && r.Method == "GET" {
 					w.Header().Add("Content-Type", "text/plain")
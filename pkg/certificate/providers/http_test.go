@@ -6,6 +6,9 @@ import (
 	"net/http"
 	"testing"
 	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func TestNotFound(t *testing.T) {
@@ -52,3 +55,35 @@ func TestFound(t *testing.T) {
 		t.Fatal("response do not matched, got", string(data))
 	}
 }
+
+func TestShared(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	leader, replica := NewHTTPProviderServer(), NewHTTPProviderServer()
+	leader.Share(client, "kube-system", "voyager-operator-acme-challenges", stopCh)
+	replica.Share(client, "kube-system", "voyager-operator-acme-challenges", stopCh)
+
+	if err := leader.Present("example.com", "token", "key"); err != nil {
+		t.Fatal("expected Nil, found", err)
+	}
+	err := wait.PollImmediate(100*time.Millisecond, 5*time.Second, func() (bool, error) {
+		keyAuth, ok := replica.keyAuth("example.com", "token")
+		return ok && keyAuth == "key", nil
+	})
+	if err != nil {
+		t.Fatal("challenge presented by leader is not served by replica")
+	}
+
+	if err := leader.CleanUp("example.com", "token", "key"); err != nil {
+		t.Fatal("expected Nil, found", err)
+	}
+	err = wait.PollImmediate(100*time.Millisecond, 5*time.Second, func() (bool, error) {
+		_, ok := replica.keyAuth("example.com", "token")
+		return !ok, nil
+	})
+	if err != nil {
+		t.Fatal("challenge cleaned up by leader is still served by replica")
+	}
+}
@@ -0,0 +1,116 @@
+package providers
+
+import (
+	"net"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+	"github.com/xenolf/lego/acmev2"
+)
+
+const (
+	rfc2136PropagationTimeout = 2 * time.Minute
+	rfc2136PollingInterval    = 2 * time.Second
+	rfc2136DefaultAlgorithm   = dns.HmacSHA256
+)
+
+// RFC2136Provider implements ChallengeProvider for `dns-01` challenge using
+// dynamic DNS updates (RFC2136), optionally signed with a TSIG key.
+// It works with authoritative servers like BIND and PowerDNS.
+type RFC2136Provider struct {
+	nameserver    string
+	zone          string
+	tsigAlgorithm string
+	tsigKey       string
+	tsigSecret    string
+}
+
+var _ acme.ChallengeProviderTimeout = &RFC2136Provider{}
+
+// NewRFC2136Provider returns a provider that sends updates to nameserver (host[:port], default port 53).
+// If zone is empty, the zone of a record is looked up from the SOA records served by nameserver.
+// tsigKey and tsigSecret must be either both set or both empty. tsigAlgorithm defaults to hmac-sha256.
+func NewRFC2136Provider(nameserver, zone, tsigAlgorithm, tsigKey, tsigSecret string) (*RFC2136Provider, error) {
+	if nameserver == "" {
+		return nil, errors.New("rfc2136 nameserver is missing")
+	}
+	if _, _, err := net.SplitHostPort(nameserver); err != nil {
+		if !strings.Contains(err.Error(), "missing port") {
+			return nil, errors.Wrapf(err, "invalid rfc2136 nameserver %s", nameserver)
+		}
+		nameserver = net.JoinHostPort(nameserver, "53")
+	}
+	if (tsigKey == "") != (tsigSecret == "") {
+		return nil, errors.New("rfc2136 tsig key and secret must be set together")
+	}
+	if tsigAlgorithm == "" {
+		tsigAlgorithm = rfc2136DefaultAlgorithm
+	}
+	p := &RFC2136Provider{
+		nameserver:    nameserver,
+		tsigAlgorithm: dns.Fqdn(tsigAlgorithm),
+	}
+	if zone != "" {
+		p.zone = dns.Fqdn(zone)
+	}
+	if tsigKey != "" {
+		p.tsigKey = dns.Fqdn(tsigKey)
+		p.tsigSecret = tsigSecret
+	}
+	return p, nil
+}
+
+// Present adds the TXT record to fulfil the dns-01 challenge.
+func (p *RFC2136Provider) Present(domain, token, keyAuth string) error {
+	fqdn, value, ttl := acme.DNS01Record(domain, keyAuth)
+	return p.update(fqdn, value, ttl, true)
+}
+
+// CleanUp removes the TXT record added by Present.
+func (p *RFC2136Provider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value, ttl := acme.DNS01Record(domain, keyAuth)
+	return p.update(fqdn, value, ttl, false)
+}
+
+// Timeout returns the timeout and interval used to check propagation of the TXT record.
+func (p *RFC2136Provider) Timeout() (timeout, interval time.Duration) {
+	return rfc2136PropagationTimeout, rfc2136PollingInterval
+}
+
+func (p *RFC2136Provider) update(fqdn, value string, ttl int, insert bool) error {
+	zone := p.zone
+	if zone == "" {
+		var err error
+		if zone, err = acme.FindZoneByFqdn(fqdn, []string{p.nameserver}); err != nil {
+			return err
+		}
+	}
+
+	rr := &dns.TXT{
+		Hdr: dns.RR_Header{Name: fqdn, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: uint32(ttl)},
+		Txt: []string{value},
+	}
+	m := new(dns.Msg)
+	m.SetUpdate(zone)
+	if insert {
+		m.Insert([]dns.RR{rr})
+	} else {
+		m.Remove([]dns.RR{rr})
+	}
+
+	c := &dns.Client{SingleInflight: true}
+	if p.tsigKey != "" {
+		m.SetTsig(p.tsigKey, p.tsigAlgorithm, 300, time.Now().Unix())
+		c.TsigSecret = map[string]string{p.tsigKey: p.tsigSecret}
+	}
+	reply, _, err := c.Exchange(m, p.nameserver)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update zone %s at %s", zone, p.nameserver)
+	}
+	if reply.Rcode != dns.RcodeSuccess {
+		return errors.Errorf("nameserver %s rejected update of zone %s with %s", p.nameserver, zone, dns.RcodeToString[reply.Rcode])
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package providers
+
+import (
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/xenolf/lego/acmev2"
+)
+
+const (
+	testTSIGKey    = "voyager."
+	testTSIGSecret = "IwBTJx9wrDp4Y1RyC3H0gA=="
+)
+
+// testZoneServer is an authoritative server for example.com that accepts TSIG signed updates.
+type testZoneServer struct {
+	mu      sync.Mutex
+	records map[string]string // fqdn -> TXT value
+}
+
+func (s *testZoneServer) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
+	m := new(dns.Msg)
+	m.SetReply(r)
+	switch {
+	case r.Opcode == dns.OpcodeUpdate:
+		if r.IsTsig() == nil || w.TsigStatus() != nil {
+			m.Rcode = dns.RcodeRefused
+			break
+		}
+		s.mu.Lock()
+		for _, rr := range r.Ns {
+			txt, ok := rr.(*dns.TXT)
+			if !ok {
+				continue
+			}
+			if txt.Hdr.Class == dns.ClassINET {
+				s.records[txt.Hdr.Name] = strings.Join(txt.Txt, "")
+			} else {
+				delete(s.records, txt.Hdr.Name)
+			}
+		}
+		s.mu.Unlock()
+		m.SetTsig(testTSIGKey, dns.HmacSHA256, 300, time.Now().Unix())
+	case r.Question[0].Qtype == dns.TypeSOA && r.Question[0].Name == "example.com.":
+		soa, _ := dns.NewRR("example.com. 300 IN SOA ns.example.com. admin.example.com. 1 3600 600 86400 300")
+		m.Answer = append(m.Answer, soa)
+	default:
+		m.Rcode = dns.RcodeNameError
+	}
+	w.WriteMsg(m)
+}
+
+func TestRFC2136Provider(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	zone := &testZoneServer{records: map[string]string{}}
+	started := make(chan struct{})
+	server := &dns.Server{
+		PacketConn:        pc,
+		Handler:           zone,
+		TsigSecret:        map[string]string{testTSIGKey: testTSIGSecret},
+		NotifyStartedFunc: func() { close(started) },
+	}
+	go server.ActivateAndServe()
+	defer server.Shutdown()
+	<-started
+
+	fqdn, value, _ := acme.DNS01Record("app.example.com", "key-auth")
+
+	p, err := NewRFC2136Provider(pc.LocalAddr().String(), "", "", "voyager", testTSIGSecret)
+	if !assert.NoError(t, err) {
+		return
+	}
+	if assert.NoError(t, p.Present("app.example.com", "token", "key-auth")) {
+		assert.Equal(t, value, zone.records[fqdn])
+	}
+	if assert.NoError(t, p.CleanUp("app.example.com", "token", "key-auth")) {
+		assert.NotContains(t, zone.records, fqdn)
+	}
+
+	// updates signed with a wrong secret are refused
+	p, err = NewRFC2136Provider(pc.LocalAddr().String(), "example.com", "", "voyager", "c2VjcmV0")
+	if assert.NoError(t, err) {
+		assert.Error(t, p.Present("app.example.com", "token", "key-auth"))
+	}
+
+	_, err = NewRFC2136Provider(pc.LocalAddr().String(), "", "", "voyager", "")
+	assert.Error(t, err)
+}
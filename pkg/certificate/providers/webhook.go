@@ -0,0 +1,124 @@
+package providers
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/xenolf/lego/acmev2"
+)
+
+const (
+	webhookPropagationTimeout = 2 * time.Minute
+	webhookPollingInterval    = 2 * time.Second
+	webhookRequestTimeout     = 30 * time.Second
+
+	WebhookActionPresent = "present"
+	WebhookActionCleanUp = "cleanup"
+)
+
+// WebhookRequest is the payload posted to a webhook dns provider.
+type WebhookRequest struct {
+	// Action is present or cleanup
+	Action string `json:"action"`
+	// Domain is the domain being validated, ie, example.com
+	Domain string `json:"domain"`
+	// FQDN is the name of the TXT record, ie, _acme-challenge.example.com.
+	FQDN string `json:"fqdn"`
+	// Value is the content of the TXT record
+	Value string `json:"value"`
+	TTL   int    `json:"ttl"`
+}
+
+// WebhookProvider implements ChallengeProvider for `dns-01` challenge by calling
+// an HTTP endpoint, which adds and removes the TXT records in any DNS system.
+type WebhookProvider struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+var _ acme.ChallengeProviderTimeout = &WebhookProvider{}
+
+// NewWebhookProvider returns a provider that posts WebhookRequest to url. If token is set, it is sent as
+// bearer token. caCert is used to verify the server, clientCert and clientKey are used for mutual TLS.
+// All of these are optional.
+func NewWebhookProvider(url, token string, caCert, clientCert, clientKey []byte) (*WebhookProvider, error) {
+	if url == "" {
+		return nil, errors.New("webhook url is missing")
+	}
+	tlsConfig := &tls.Config{}
+	if len(caCert) > 0 {
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("webhook ca certificate is invalid")
+		}
+	}
+	if len(clientCert) > 0 || len(clientKey) > 0 {
+		pair, err := tls.X509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "webhook client certificate is invalid")
+		}
+		tlsConfig.Certificates = []tls.Certificate{pair}
+	}
+	return &WebhookProvider{
+		url:   url,
+		token: token,
+		client: &http.Client{
+			Timeout:   webhookRequestTimeout,
+			Transport: &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: tlsConfig},
+		},
+	}, nil
+}
+
+// Present asks the webhook to add the TXT record to fulfil the dns-01 challenge.
+func (p *WebhookProvider) Present(domain, token, keyAuth string) error {
+	return p.call(WebhookActionPresent, domain, keyAuth)
+}
+
+// CleanUp asks the webhook to remove the TXT record added by Present.
+func (p *WebhookProvider) CleanUp(domain, token, keyAuth string) error {
+	return p.call(WebhookActionCleanUp, domain, keyAuth)
+}
+
+// Timeout returns the timeout and interval used to check propagation of the TXT record.
+func (p *WebhookProvider) Timeout() (timeout, interval time.Duration) {
+	return webhookPropagationTimeout, webhookPollingInterval
+}
+
+func (p *WebhookProvider) call(action, domain, keyAuth string) error {
+	fqdn, value, ttl := acme.DNS01Record(domain, keyAuth)
+	body, err := json.Marshal(WebhookRequest{
+		Action: action,
+		Domain: domain,
+		FQDN:   fqdn,
+		Value:  value,
+		TTL:    ttl,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to call webhook for %s", action)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf("webhook returned status %s for %s: %s", resp.Status, action, bytes.TrimSpace(msg))
+	}
+	return nil
+}
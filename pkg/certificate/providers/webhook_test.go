@@ -0,0 +1,73 @@
+package providers
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xenolf/lego/acmev2"
+	"k8s.io/client-go/util/cert"
+)
+
+func TestWebhookProvider(t *testing.T) {
+	clientKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCert, err := cert.NewSelfSignedCACert(cert.Config{CommonName: "voyager"}, clientKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var requests []WebhookRequest
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret-token" {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+		var req WebhookRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		requests = append(requests, req)
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	p, err := NewWebhookProvider(server.URL, "secret-token", caCert, cert.EncodeCertPEM(clientCert), cert.EncodePrivateKeyPEM(clientKey))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, p.Present("example.com", "token", "key-auth"))
+	assert.NoError(t, p.CleanUp("example.com", "token", "key-auth"))
+
+	fqdn, value, ttl := acme.DNS01Record("example.com", "key-auth")
+	assert.Equal(t, []WebhookRequest{
+		{Action: WebhookActionPresent, Domain: "example.com", FQDN: fqdn, Value: value, TTL: ttl},
+		{Action: WebhookActionCleanUp, Domain: "example.com", FQDN: fqdn, Value: value, TTL: ttl},
+	}, requests)
+
+	// wrong token
+	p, err = NewWebhookProvider(server.URL, "invalid", caCert, cert.EncodeCertPEM(clientCert), cert.EncodePrivateKeyPEM(clientKey))
+	if assert.NoError(t, err) {
+		assert.Error(t, p.Present("example.com", "token", "key-auth"))
+	}
+
+	// no client certificate
+	p, err = NewWebhookProvider(server.URL, "secret-token", caCert, nil, nil)
+	if assert.NoError(t, err) {
+		assert.Error(t, p.Present("example.com", "token", "key-auth"))
+	}
+}
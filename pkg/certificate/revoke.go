@@ -0,0 +1,142 @@
+package certificate
+
+import (
+	"crypto/x509"
+
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	"github.com/appscode/voyager/client/clientset/versioned/typed/voyager/v1beta1/util"
+	"github.com/appscode/voyager/pkg/eventer"
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// revocationReasonCodes maps revocation reasons to their codes in RFC 5280 section 5.3.1.
+var revocationReasonCodes = map[api.RevocationReason]uint{
+	"":                                       0,
+	api.RevocationReasonUnspecified:          0,
+	api.RevocationReasonKeyCompromise:        1,
+	api.RevocationReasonAffiliationChanged:   3,
+	api.RevocationReasonSuperseded:           4,
+	api.RevocationReasonCessationOfOperation: 5,
+}
+
+// isRevoked returns true if crt is the certificate revoked for spec.revoke.
+func isRevoked(crd *api.Certificate, crt *x509.Certificate) bool {
+	return crd.Status.Revocation != nil && crt != nil && crd.Status.Revocation.SerialNumber == crt.SerialNumber.String()
+}
+
+// revoke revokes the current certificate, and the ECDSA certificate of a dual key Certificate,
+// at the ACME server and records the revocation in status.
+func (c *Controller) revoke(pemCrt []byte) error {
+	reason := c.crd.Spec.Revoke.Reason
+	code, ok := revocationReasonCodes[reason]
+	if !ok {
+		return errors.Errorf("revocation reason %s is unsupported", reason)
+	}
+	if reason == "" {
+		reason = api.RevocationReasonUnspecified
+	}
+
+	if err := c.revokeAtServer(pemCrt, code); err != nil {
+		return err
+	}
+
+	crd, _, err := util.PatchCertificate(c.VoyagerClient.VoyagerV1beta1(), c.crd, func(in *api.Certificate) *api.Certificate {
+		t := metav1.Now()
+		in.Status.Revocation = &api.CertificateRevocationStatus{
+			SerialNumber:   c.curCert.SerialNumber.String(),
+			Reason:         reason,
+			RevocationTime: t,
+		}
+		cond := api.CertificateCondition{
+			Type:           api.CertificateRevoked,
+			LastUpdateTime: t,
+			Reason:         string(reason),
+			Message:        "Certificate " + c.curCert.SerialNumber.String() + " is revoked",
+		}
+		found := false
+		for i := range in.Status.Conditions {
+			if in.Status.Conditions[i].Type == api.CertificateRevoked {
+				in.Status.Conditions[i] = cond
+				found = true
+			}
+		}
+		if !found {
+			in.Status.Conditions = append(in.Status.Conditions, cond)
+		}
+		return in
+	})
+	if err != nil {
+		return err
+	}
+	c.crd = crd
+	c.recorder.Eventf(
+		c.crd.ObjectReference(),
+		core.EventTypeNormal,
+		eventer.EventReasonCertificateRevokeSuccessful,
+		"Successfully revoked certificate %s. Reason: %s",
+		c.curCert.SerialNumber,
+		reason,
+	)
+	return nil
+}
+
+// revokeAtServer revokes the current certificate, and the ECDSA certificate of a dual key Certificate,
+// at the ACME server with the given reason code.
+func (c *Controller) revokeAtServer(pemCrt []byte, code uint) error {
+	if c.acmeAccount == nil {
+		if err := c.loadACMEAccount(); err != nil {
+			return err
+		}
+	}
+	if err := c.acmeAccount.revoke(pemCrt, code); err != nil {
+		return errors.Wrapf(err, "failed to revoke certificate %s", c.curCert.SerialNumber)
+	}
+	if c.crd.Spec.DualKey {
+		ecdsaCrt, _, err := c.store.GetECDSA(c.crd)
+		if err != nil {
+			return err
+		}
+		if ecdsaCrt != nil {
+			if err := c.acmeAccount.revoke(ecdsaCrt, code); err != nil {
+				return errors.Wrap(err, "failed to revoke ECDSA certificate")
+			}
+		}
+	}
+	return nil
+}
+
+// processRevocation revokes the current certificate if spec.revoke is set and clears the revocation
+// status once spec.revoke is removed. It returns true, if the current certificate is revoked.
+func (c *Controller) processRevocation(pemCrt []byte) (bool, error) {
+	revoked := isRevoked(c.crd, c.curCert)
+	if c.crd.Spec.Revoke == nil {
+		if c.crd.Status.Revocation == nil {
+			return revoked, nil
+		}
+		crd, _, err := util.PatchCertificate(c.VoyagerClient.VoyagerV1beta1(), c.crd, func(in *api.Certificate) *api.Certificate {
+			in.Status.Revocation = nil
+			return in
+		})
+		if err != nil {
+			return revoked, err
+		}
+		c.crd = crd
+		return revoked, nil
+	}
+
+	if c.crd.Status.Revocation == nil && c.curCert != nil {
+		if err := c.revoke(pemCrt); err != nil {
+			c.recorder.Event(
+				c.crd.ObjectReference(),
+				core.EventTypeWarning,
+				eventer.EventReasonCertificateRevokeFailed,
+				err.Error(),
+			)
+			return false, c.processError(err)
+		}
+		revoked = true
+	}
+	return revoked, nil
+}
@@ -0,0 +1,48 @@
+package certificate
+
+import (
+	"crypto/x509"
+	"math/big"
+	"testing"
+
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	vfake "github.com/appscode/voyager/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestIsRevoked(t *testing.T) {
+	crt := &x509.Certificate{SerialNumber: big.NewInt(42)}
+	crd := &api.Certificate{}
+	assert.False(t, isRevoked(crd, crt))
+
+	crd.Status.Revocation = &api.CertificateRevocationStatus{SerialNumber: "42"}
+	assert.True(t, isRevoked(crd, crt))
+	assert.False(t, isRevoked(crd, nil))
+
+	// reissued certificate
+	assert.False(t, isRevoked(crd, &x509.Certificate{SerialNumber: big.NewInt(43)}))
+}
+
+func TestProcessRevokedWithoutCertificate(t *testing.T) {
+	crd := &api.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: "revoked", Namespace: "default"},
+		Spec: api.CertificateSpec{
+			Domains:            []string{"example.com"},
+			ACMEUserSecretName: "acme-account",
+			Revoke:             &api.CertificateRevocation{},
+		},
+	}
+	kubeClient, extClient := fake.NewSimpleClientset(), vfake.NewSimpleClientset(crd)
+	store, err := NewCertStore(kubeClient, extClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctrl := &Controller{KubeClient: kubeClient, VoyagerClient: extClient, crd: crd, store: store}
+
+	// revoked certificate was deleted and is not reissued
+	assert.NoError(t, ctrl.Process())
+	_, ok := ctrl.NextRenewalTime()
+	assert.False(t, ok)
+}
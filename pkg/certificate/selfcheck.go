@@ -0,0 +1,189 @@
+package certificate
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	"github.com/appscode/voyager/client/clientset/versioned/typed/voyager/v1beta1/util"
+	"github.com/pkg/errors"
+	"github.com/xenolf/lego/acmev2"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// selfCheckBackoff retries the self check of a challenge for about 4 minutes.
+var selfCheckBackoff = wait.Backoff{
+	Duration: 2 * time.Second,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    8,
+}
+
+// selfCheckProvider checks that a challenge is reachable the same way the ACME server checks it,
+// before lego asks the ACME server to validate the challenge. Failed validations count against the
+// rate limits of ACME servers, while self checks can be retried freely.
+type selfCheckProvider struct {
+	acme.ChallengeProvider
+	ctrl  *Controller
+	check func(domain, token, keyAuth string) error
+}
+
+var _ acme.ChallengeProviderTimeout = &selfCheckProvider{}
+
+func (p *selfCheckProvider) Present(domain, token, keyAuth string) error {
+	if err := p.ChallengeProvider.Present(domain, token, keyAuth); err != nil {
+		return err
+	}
+
+	p.ctrl.setSelfCheckCondition(api.CertificateSelfCheckPending, "Checking challenge for "+domain)
+	var lastErr error
+	err := wait.ExponentialBackoff(selfCheckBackoff, func() (bool, error) {
+		lastErr = p.check(domain, token, keyAuth)
+		return lastErr == nil, nil
+	})
+	if err != nil {
+		err = errors.Wrapf(lastErr, "self check failed for %s", domain)
+		p.ctrl.setSelfCheckCondition(api.CertificateSelfCheckFailed, err.Error())
+		// lego cleans up only the challenges that are presented successfully
+		p.ChallengeProvider.CleanUp(domain, token, keyAuth)
+		return err
+	}
+	p.ctrl.setSelfCheckCondition(api.CertificateSelfCheckPassed, "Challenge for "+domain+" is reachable")
+	return nil
+}
+
+// Timeout returns the propagation timeout of the wrapped dns provider, or lego defaults.
+func (p *selfCheckProvider) Timeout() (timeout, interval time.Duration) {
+	if provider, ok := p.ChallengeProvider.(acme.ChallengeProviderTimeout); ok {
+		return provider.Timeout()
+	}
+	return 60 * time.Second, 2 * time.Second
+}
+
+func (c *Controller) withSelfCheck(provider acme.ChallengeProvider, check func(domain, token, keyAuth string) error) acme.ChallengeProvider {
+	if c.crd.Spec.Issuer != nil && c.crd.Spec.Issuer.ACME != nil && c.crd.Spec.Issuer.ACME.SkipSelfCheck {
+		return provider
+	}
+	return &selfCheckProvider{ChallengeProvider: provider, ctrl: c, check: check}
+}
+
+// checkHTTPChallenge fetches the key authorization through the public addresses of the Ingress, to check
+// that the challenge route is live, and then through the domain, to check that DNS points to the Ingress.
+func (c *Controller) checkHTTPChallenge(domain, token, keyAuth string) error {
+	path := acme.HTTP01ChallengePath(token)
+	addrs, err := c.ingressAddresses()
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if err := fetchKeyAuthorization("http://"+addr+path, domain, keyAuth); err != nil {
+			return errors.Wrapf(err, "challenge is not served by ingress address %s", addr)
+		}
+	}
+	if err := fetchKeyAuthorization("http://"+domain+path, domain, keyAuth); err != nil {
+		return errors.Wrapf(err, "challenge is not served via domain %s, check that its dns record points to the ingress", domain)
+	}
+	return nil
+}
+
+// checkDNSChallenge checks that the authoritative nameservers of the domain serve the TXT record.
+func (c *Controller) checkDNSChallenge(domain, token, keyAuth string) error {
+	fqdn, value, _ := acme.DNS01Record(domain, keyAuth)
+	ok, err := acme.PreCheckDNS(fqdn, value)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.Errorf("authoritative nameservers do not serve TXT record %s yet", fqdn)
+	}
+	return nil
+}
+
+// ingressAddresses returns the load balancer addresses of the Ingress used for http-01 challenge.
+func (c *Controller) ingressAddresses() ([]string, error) {
+	ref := c.crd.Spec.ChallengeProvider.HTTP.Ingress
+	var status core.LoadBalancerStatus
+	switch ref.APIVersion {
+	case api.SchemeGroupVersion.String():
+		ing, err := c.VoyagerClient.VoyagerV1beta1().Ingresses(c.crd.Namespace).Get(ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		status = ing.Status.LoadBalancer
+	case "extensions/v1beta1":
+		ing, err := c.KubeClient.ExtensionsV1beta1().Ingresses(c.crd.Namespace).Get(ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		status = ing.Status.LoadBalancer
+	default:
+		return nil, errors.New("HTTP Certificate resolver do not have any ingress reference or invalid ingress reference")
+	}
+
+	var addrs []string
+	for _, lb := range status.Ingress {
+		if lb.IP != "" {
+			addrs = append(addrs, lb.IP)
+		} else if lb.Hostname != "" {
+			addrs = append(addrs, lb.Hostname)
+		}
+	}
+	return addrs, nil
+}
+
+var selfCheckClient = &http.Client{Timeout: 10 * time.Second}
+
+func fetchKeyAuthorization(url, host, keyAuth string) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Host = host
+	resp, err := selfCheckClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("%s returned status %s", url, resp.Status)
+	}
+	if string(bytes.TrimSpace(body)) != keyAuth {
+		return errors.Errorf("%s returned unexpected key authorization", url)
+	}
+	return nil
+}
+
+// setSelfCheckCondition sets condition of type condType and removes other self check conditions.
+func (c *Controller) setSelfCheckCondition(condType api.RequestConditionType, reason string) {
+	crd, _, err := util.PatchCertificate(c.VoyagerClient.VoyagerV1beta1(), c.crd, func(in *api.Certificate) *api.Certificate {
+		in.Status.Conditions = upsertSelfCheckCondition(in.Status.Conditions, condType, reason)
+		return in
+	})
+	if err == nil {
+		c.crd = crd
+	}
+}
+
+func upsertSelfCheckCondition(conditions []api.CertificateCondition, condType api.RequestConditionType, reason string) []api.CertificateCondition {
+	result := make([]api.CertificateCondition, 0, len(conditions)+1)
+	for _, cond := range conditions {
+		switch cond.Type {
+		case api.CertificateSelfCheckPending, api.CertificateSelfCheckPassed, api.CertificateSelfCheckFailed:
+			continue
+		}
+		result = append(result, cond)
+	}
+	return append(result, api.CertificateCondition{
+		Type:           condType,
+		LastUpdateTime: metav1.Now(),
+		Reason:         reason,
+	})
+}
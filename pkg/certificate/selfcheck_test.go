@@ -0,0 +1,91 @@
+package certificate
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	"github.com/appscode/voyager/client/clientset/versioned/fake"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type fakeChallengeProvider struct {
+	presented map[string]string
+}
+
+func (p *fakeChallengeProvider) Present(domain, token, keyAuth string) error {
+	p.presented[domain] = keyAuth
+	return nil
+}
+
+func (p *fakeChallengeProvider) CleanUp(domain, token, keyAuth string) error {
+	delete(p.presented, domain)
+	return nil
+}
+
+func TestFetchKeyAuthorization(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host == "example.com" && r.URL.Path == "/.well-known/acme-challenge/token" {
+			fmt.Fprintln(w, "token.key")
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	assert.NoError(t, fetchKeyAuthorization(server.URL+"/.well-known/acme-challenge/token", "example.com", "token.key"))
+	assert.Error(t, fetchKeyAuthorization(server.URL+"/.well-known/acme-challenge/token", "example.com", "other.key"))
+	assert.Error(t, fetchKeyAuthorization(server.URL+"/.well-known/acme-challenge/token", "www.example.com", "token.key"))
+}
+
+func TestSelfCheckProvider(t *testing.T) {
+	selfCheckBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+
+	crd := &api.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+	}
+	ctrl := &Controller{VoyagerClient: fake.NewSimpleClientset(crd), crd: crd}
+	provider := &fakeChallengeProvider{presented: map[string]string{}}
+
+	// passes on the last attempt
+	attempts := 0
+	p := ctrl.withSelfCheck(provider, func(domain, token, keyAuth string) error {
+		if attempts++; attempts < 3 {
+			return errors.New("not ready")
+		}
+		return nil
+	})
+	if assert.NoError(t, p.Present("example.com", "token", "key")) {
+		assert.Contains(t, provider.presented, "example.com")
+	}
+
+	// fails and cleans up the challenge
+	p = ctrl.withSelfCheck(provider, func(domain, token, keyAuth string) error {
+		return errors.New("not ready")
+	})
+	if assert.Error(t, p.Present("www.example.com", "token", "key")) {
+		assert.NotContains(t, provider.presented, "www.example.com")
+	}
+
+	// skipped
+	ctrl.crd = crd.DeepCopy()
+	ctrl.crd.Spec.Issuer = &api.CertificateIssuer{ACME: &api.ACMEIssuer{SkipSelfCheck: true}}
+	assert.Equal(t, provider, ctrl.withSelfCheck(provider, nil))
+}
+
+func TestUpsertSelfCheckCondition(t *testing.T) {
+	conditions := []api.CertificateCondition{{Type: api.CertificateIssued}}
+	conditions = upsertSelfCheckCondition(conditions, api.CertificateSelfCheckPending, "checking")
+	conditions = upsertSelfCheckCondition(conditions, api.CertificateSelfCheckFailed, "failed")
+	if assert.Len(t, conditions, 2) {
+		assert.Equal(t, api.CertificateIssued, conditions[0].Type)
+		assert.Equal(t, api.CertificateSelfCheckFailed, conditions[1].Type)
+		assert.Equal(t, "failed", conditions[1].Reason)
+	}
+}
@@ -1,9 +1,6 @@
 package certificate
 
 import (
-	"crypto/x509"
-	"encoding/pem"
-	"io/ioutil"
 	"os"
 	"path"
 
@@ -18,12 +15,15 @@ import (
 	k8serror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	cert_util "k8s.io/client-go/util/cert"
 )
 
 type CertStore struct {
 	KubeClient    kubernetes.Interface
 	VoyagerClient cs.Interface
-	VaultClient   *vault.Client
+	// VaultClient is authenticated via VAULT_TOKEN or VAULT_KUBERNETES_ROLE. It is nil, if VAULT_ADDR is not set.
+	// Use vaultClient to get a client with a valid token.
+	VaultClient *vault.Client
 }
 
 func NewCertStore(kubeClient kubernetes.Interface, voyagerClient cs.Interface) (*CertStore, error) {
@@ -32,77 +32,128 @@ func NewCertStore(kubeClient kubernetes.Interface, voyagerClient cs.Interface) (
 		VoyagerClient: voyagerClient,
 	}
 
-	var err error
 	if os.Getenv(vault.EnvVaultAddress) != "" {
-		store.VaultClient, err = vault.NewClient(vault.DefaultConfig())
+		session, err := vaultSessionFor(kubeClient, nil, "")
 		if err != nil {
 			return nil, err
 		}
-		if os.Getenv(vault.EnvVaultToken) == "" {
-			token, err := ioutil.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/token")
-			if err != nil {
-				return nil, err
-			}
-			store.VaultClient.SetToken(string(token))
-		}
+		store.VaultClient = session.client
 	}
 	return store, nil
 }
 
+// vaultClient returns the Vault client used for storage of a Certificate in namespace, after logging in
+// or renewing its token if needed. If storage is nil, the client authenticated via environment variables is returned.
+func (s *CertStore) vaultClient(storage *api.VaultStore, namespace string) (*vault.Client, error) {
+	session, err := vaultSessionFor(s.KubeClient, storage, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return session.Client()
+}
+
 func (s *CertStore) Get(crd *api.Certificate) (pemCrt, pemKey []byte, err error) {
+	data, err := s.read(crd)
+	if err != nil || data == nil {
+		return nil, nil, err
+	}
+	if pemCrt = data[core.TLSCertKey]; pemCrt == nil {
+		return nil, nil, errors.Errorf("secret %s/%s is missing tls.crt", crd.Namespace, crd.SecretName())
+	}
+	if pemKey = data[core.TLSPrivateKeyKey]; pemKey == nil {
+		return nil, nil, errors.Errorf("secret %s/%s is missing tls.key", crd.Namespace, crd.SecretName())
+	}
+	return
+}
+
+// GetECDSA returns the ECDSA certificate stored along with the RSA certificate of a dual key Certificate.
+// It returns nil if no ECDSA certificate is stored.
+func (s *CertStore) GetECDSA(crd *api.Certificate) (pemCrt, pemKey []byte, err error) {
+	data, err := s.read(crd)
+	if err != nil || data == nil {
+		return nil, nil, err
+	}
+	pemCrt, pemKey = data[api.TLSECDSACertKey], data[api.TLSECDSAPrivateKeyKey]
+	if pemCrt == nil && pemKey == nil {
+		return nil, nil, nil
+	}
+	if pemCrt == nil {
+		return nil, nil, errors.Errorf("secret %s/%s is missing %s", crd.Namespace, crd.SecretName(), api.TLSECDSACertKey)
+	}
+	if pemKey == nil {
+		return nil, nil, errors.Errorf("secret %s/%s is missing %s", crd.Namespace, crd.SecretName(), api.TLSECDSAPrivateKeyKey)
+	}
+	return
+}
+
+// read returns the stored data of a Certificate, or nil if nothing is stored yet.
+func (s *CertStore) read(crd *api.Certificate) (map[string][]byte, error) {
 	if crd.Spec.Storage.Vault != nil {
-		var secret *vault.Secret
-		secret, err = s.VaultClient.Logical().Read(path.Join(crd.Spec.Storage.Vault.Prefix, crd.Namespace, crd.SecretName()))
+		client, err := s.vaultClient(crd.Spec.Storage.Vault, crd.Namespace)
 		if err != nil {
-			return
-		}
-		if secret == nil && err == nil {
-			return nil, nil, nil
-		}
-
-		if data, found := secret.Data[core.TLSCertKey]; !found {
-			err = errors.Errorf("secret %s/%s is missing tls.crt", crd.Namespace, crd.SecretName())
-			return
-		} else {
-			pemCrt = []byte(data.(string))
+			return nil, err
 		}
-		if data, found := secret.Data[core.TLSPrivateKeyKey]; !found {
-			err = errors.Errorf("secret %s/%s is missing tls.key", crd.Namespace, crd.SecretName())
-			return
-		} else {
-			pemKey = []byte(data.(string))
+		secret, err := client.Logical().Read(path.Join(crd.Spec.Storage.Vault.Prefix, crd.Namespace, crd.SecretName()))
+		if err != nil {
+			return nil, err
 		}
-	} else {
-		var secret *core.Secret
-		secret, err = s.KubeClient.CoreV1().Secrets(crd.Namespace).Get(crd.SecretName(), metav1.GetOptions{})
-		if k8serror.IsNotFound(err) {
-			return nil, nil, nil
+		if secret == nil {
+			return nil, nil
 		}
-		if err == nil {
-			if data, found := secret.Data[core.TLSCertKey]; !found {
-				err = errors.Errorf("secret %s/%s is missing tls.crt", crd.Namespace, crd.SecretName())
-				return
-			} else {
-				pemCrt = data
-			}
-			if data, found := secret.Data[core.TLSPrivateKeyKey]; !found {
-				err = errors.Errorf("secret %s/%s is missing tls.key", crd.Namespace, crd.SecretName())
-				return
-			} else {
-				pemKey = data
+		data := make(map[string][]byte, len(secret.Data))
+		for k, v := range secret.Data {
+			if str, ok := v.(string); ok {
+				data[k] = []byte(str)
 			}
 		}
+		return data, nil
 	}
-	return
+
+	secret, err := s.KubeClient.CoreV1().Secrets(crd.Namespace).Get(crd.SecretName(), metav1.GetOptions{})
+	if k8serror.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return secret.Data, nil
 }
 
-func (s *CertStore) Save(crd *api.Certificate, cert acme.CertificateResource) error {
+// Delete removes the stored certificate of a Certificate. It is not an error, if nothing is stored.
+func (s *CertStore) Delete(crd *api.Certificate) error {
+	if crd.Spec.Storage.Vault != nil {
+		client, err := s.vaultClient(crd.Spec.Storage.Vault, crd.Namespace)
+		if err != nil {
+			return err
+		}
+		_, err = client.Logical().Delete(path.Join(crd.Spec.Storage.Vault.Prefix, crd.Namespace, crd.SecretName()))
+		return err
+	}
+
+	err := s.KubeClient.CoreV1().Secrets(crd.Namespace).Delete(crd.SecretName(), &metav1.DeleteOptions{})
+	if err != nil && !k8serror.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// Save stores the issued certificate. ecdsaCert is the ECDSA certificate of a dual key Certificate, nil otherwise.
+// acmeUser is the ACME account used to issue the certificate, nil for other issuers.
+func (s *CertStore) Save(crd *api.Certificate, cert acme.CertificateResource, ecdsaCert *acme.CertificateResource, acmeUser *ACMEUser) error {
 	if crd.Spec.Storage.Vault != nil {
 		data := map[string]interface{}{
 			core.TLSCertKey:       string(cert.Certificate),
 			core.TLSPrivateKeyKey: string(cert.PrivateKey),
 		}
-		_, err := s.VaultClient.Logical().Write(path.Join(crd.Spec.Storage.Vault.Prefix, crd.Namespace, crd.SecretName()), data)
+		if ecdsaCert != nil {
+			data[api.TLSECDSACertKey] = string(ecdsaCert.Certificate)
+			data[api.TLSECDSAPrivateKeyKey] = string(ecdsaCert.PrivateKey)
+		}
+		client, err := s.vaultClient(crd.Spec.Storage.Vault, crd.Namespace)
+		if err != nil {
+			return err
+		}
+		_, err = client.Logical().Write(path.Join(crd.Spec.Storage.Vault.Prefix, crd.Namespace, crd.SecretName()), data)
 		if err != nil {
 			return err
 		}
@@ -116,6 +167,13 @@ func (s *CertStore) Save(crd *api.Certificate, cert acme.CertificateResource) er
 				}
 				in.Data[core.TLSCertKey] = cert.Certificate
 				in.Data[core.TLSPrivateKeyKey] = cert.PrivateKey
+				if ecdsaCert != nil {
+					in.Data[api.TLSECDSACertKey] = ecdsaCert.Certificate
+					in.Data[api.TLSECDSAPrivateKeyKey] = ecdsaCert.PrivateKey
+				} else {
+					delete(in.Data, api.TLSECDSACertKey)
+					delete(in.Data, api.TLSECDSAPrivateKeyKey)
+				}
 				return in
 			})
 		if err != nil {
@@ -124,14 +182,15 @@ func (s *CertStore) Save(crd *api.Certificate, cert acme.CertificateResource) er
 	}
 
 	// Decode cert
-	pemBlock, _ := pem.Decode(cert.Certificate)
-	crt, err := x509.ParseCertificate(pemBlock.Bytes)
+	chain, err := cert_util.ParseCertsPEM(cert.Certificate)
 	if err != nil {
 		return errors.Errorf("failed to parse tls.crt for Certificate %s/%s. Reason: %s", crd.Namespace, crd.Name, err)
 	}
+	crt := chain[0]
 	_, _, err = util.PatchCertificate(s.VoyagerClient.VoyagerV1beta1(), crd, func(in *api.Certificate) *api.Certificate {
 		// Update certificate data to add Details Information
 		t := metav1.Now()
+		keyAlgorithm, keySize := crd.KeyParameters()
 		in.Status.LastIssuedCertificate = &api.CertificateDetails{
 			SerialNumber:  crt.SerialNumber.String(),
 			NotBefore:     metav1.NewTime(crt.NotBefore),
@@ -139,7 +198,16 @@ func (s *CertStore) Save(crd *api.Certificate, cert acme.CertificateResource) er
 			CertURL:       cert.CertURL,
 			CertStableURL: cert.CertStableURL,
 			AccountRef:    cert.AccountRef,
+			KeyAlgorithm:  keyAlgorithm,
+			KeySize:       keySize,
+			ChainIssuer:   chain[len(chain)-1].Issuer.CommonName,
+		}
+		if acmeUser != nil {
+			in.Status.LastIssuedCertificate.ACMEServerURL = acmeUser.ServerURL
+			in.Status.LastIssuedCertificate.ExternalAccountKeyID = acmeUser.ExternalAccountKeyID
 		}
+		next := metav1.NewTime(crd.RenewalTime(crt)).Rfc3339Copy()
+		in.Status.NextRenewalTime = &next
 
 		found := false
 		for i := range in.Status.Conditions {
@@ -0,0 +1,77 @@
+package certificate
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/xenolf/lego/acmev2"
+)
+
+// newACMEHTTPClient returns the http client for an ACME server. If caBundle is given, only this
+// client trusts the PEM encoded caBundle of a private ACME server.
+func newACMEHTTPClient(caBundle []byte) (*http.Client, error) {
+	t := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		Dial: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).Dial,
+		TLSHandshakeTimeout:   15 * time.Second,
+		ResponseHeaderTimeout: 15 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	if len(caBundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, errors.New("acme ca bundle contains no certificate")
+		}
+		t.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	return &http.Client{Transport: t}, nil
+}
+
+// acmeTransport routes requests to private ACME servers through the transports of their http clients.
+// lego has no per client configuration and sends all requests via its package level acme.HTTPClient,
+// so it is installed there once. Requests to other servers use the default transport of lego.
+type acmeTransport struct {
+	base  http.RoundTripper
+	mu    sync.RWMutex
+	hosts map[string]http.RoundTripper
+}
+
+var legoTransport = &acmeTransport{
+	base:  acme.HTTPClient.Transport,
+	hosts: map[string]http.RoundTripper{},
+}
+
+func init() {
+	acme.HTTPClient.Transport = legoTransport
+}
+
+func (t *acmeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.RLock()
+	rt, found := t.hosts[req.URL.Host]
+	t.mu.RUnlock()
+	if found {
+		return rt.RoundTrip(req)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// route sends requests to the host of serverURL through client.
+func (t *acmeTransport) route(serverURL string, client *http.Client) error {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return errors.Wrapf(err, "invalid acme server url %s", serverURL)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.hosts[u.Host] = client.Transport
+	return nil
+}
@@ -0,0 +1,42 @@
+package certificate
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestACMETransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	transport := &acmeTransport{
+		base:  http.DefaultTransport,
+		hosts: map[string]http.RoundTripper{},
+	}
+	client := &http.Client{Transport: transport}
+	_, err := client.Get(server.URL + "/directory")
+	assert.Error(t, err, "server certificate is not trusted")
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	acmeClient, err := newACMEHTTPClient(caBundle)
+	if !assert.NoError(t, err) {
+		return
+	}
+	resp, err := acmeClient.Get(server.URL + "/directory")
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+	}
+
+	assert.NoError(t, transport.route(server.URL+"/directory", acmeClient))
+	resp, err = client.Get(server.URL + "/directory")
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+	}
+
+	_, err = newACMEHTTPClient([]byte("invalid"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,224 @@
+package certificate
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	vault "github.com/hashicorp/vault/api"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// EnvVaultNamespace is the Vault Enterprise namespace used, unless a Certificate sets spec.storage.vault.namespace.
+	EnvVaultNamespace = "VAULT_NAMESPACE"
+	// EnvVaultKubernetesRole logs in to Vault via kubernetes auth method with this role, if VAULT_TOKEN is not set.
+	EnvVaultKubernetesRole = "VAULT_KUBERNETES_ROLE"
+	// EnvVaultKubernetesMountPath is the mount path of kubernetes auth method. Default is kubernetes.
+	EnvVaultKubernetesMountPath = "VAULT_KUBERNETES_MOUNT_PATH"
+
+	vaultNamespaceHeader    = "X-Vault-Namespace"
+	serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+// vaultLogin logs in to Vault via an auth method and returns the new token.
+type vaultLogin func(client *vault.Client) (*vault.SecretAuth, error)
+
+// vaultSession is a Vault client whose token is obtained via an auth method. The token is renewed
+// after two thirds of its ttl, or a new token is obtained if it can't be renewed anymore.
+type vaultSession struct {
+	mu        sync.Mutex
+	client    *vault.Client
+	login     vaultLogin // nil if token is set via VAULT_TOKEN
+	lease     time.Duration
+	renewable bool
+	refreshAt time.Time // zero if token never expires
+	expiry    time.Time
+}
+
+var (
+	vaultSessionsMu sync.Mutex
+	// vaultSessions are shared by the CertStores of a process, so that tokens are reused and
+	// renewed across reconciles instead of logging in for each Certificate.
+	vaultSessions = map[string]*vaultSession{}
+)
+
+// Client returns the Vault client with a valid token.
+func (s *vaultSession) Client() (*vault.Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.login == nil {
+		return s.client, nil
+	}
+	now := time.Now()
+	if s.client.Token() != "" && (s.refreshAt.IsZero() || now.Before(s.refreshAt)) {
+		return s.client, nil
+	}
+	if s.client.Token() != "" && s.renewable && now.Before(s.expiry) {
+		secret, err := s.client.Auth().Token().RenewSelf(0)
+		// a token renewed for less than half of its original ttl is about to reach its max ttl
+		if err == nil && secret != nil && secret.Auth != nil &&
+			time.Duration(secret.Auth.LeaseDuration)*time.Second >= s.lease/2 {
+			s.setToken(secret.Auth, false)
+			return s.client, nil
+		}
+	}
+
+	s.client.ClearToken()
+	auth, err := s.login(s.client)
+	if err != nil {
+		return nil, err
+	}
+	s.setToken(auth, true)
+	return s.client, nil
+}
+
+func (s *vaultSession) setToken(auth *vault.SecretAuth, login bool) {
+	now := time.Now()
+	lease := time.Duration(auth.LeaseDuration) * time.Second
+	if login {
+		s.lease = lease
+	}
+	s.client.SetToken(auth.ClientToken)
+	s.renewable = auth.Renewable
+	s.refreshAt, s.expiry = time.Time{}, time.Time{}
+	if lease > 0 {
+		s.refreshAt = now.Add(lease * 2 / 3)
+		s.expiry = now.Add(lease)
+	}
+}
+
+// getVaultSession returns the shared session for key, creating it via newSession if needed.
+func getVaultSession(key string, newSession func() (*vaultSession, error)) (*vaultSession, error) {
+	vaultSessionsMu.Lock()
+	defer vaultSessionsMu.Unlock()
+
+	if s, found := vaultSessions[key]; found {
+		return s, nil
+	}
+	s, err := newSession()
+	if err != nil {
+		return nil, err
+	}
+	vaultSessions[key] = s
+	return s, nil
+}
+
+// newVaultSession creates a session for the Vault server at VAULT_ADDR. If login is nil, token is read from VAULT_TOKEN.
+func newVaultSession(namespace string, login vaultLogin) (*vaultSession, error) {
+	client, err := vault.NewClient(vault.DefaultConfig())
+	if err != nil {
+		return nil, err
+	}
+	if namespace != "" {
+		client.SetHeaders(http.Header{vaultNamespaceHeader: []string{namespace}})
+	}
+	if login != nil {
+		client.ClearToken()
+	}
+	return &vaultSession{client: client, login: login}, nil
+}
+
+// defaultVaultLogin returns the auth method configured via environment variables, or nil to use VAULT_TOKEN.
+func defaultVaultLogin() vaultLogin {
+	if os.Getenv(vault.EnvVaultToken) != "" {
+		return nil
+	}
+	if role := os.Getenv(EnvVaultKubernetesRole); role != "" {
+		return kubernetesLogin(role, os.Getenv(EnvVaultKubernetesMountPath))
+	}
+	return nil
+}
+
+// vaultSessionFor returns the session used to store the certificates of a Certificate in namespace.
+// If storage has no auth, the auth method configured via environment variables is used.
+func vaultSessionFor(kubeClient kubernetes.Interface, storage *api.VaultStore, namespace string) (*vaultSession, error) {
+	vaultNamespace := os.Getenv(EnvVaultNamespace)
+	if storage != nil && storage.Namespace != "" {
+		vaultNamespace = storage.Namespace
+	}
+
+	var auth *api.VaultAuth
+	if storage != nil {
+		auth = storage.Auth
+	}
+	switch {
+	case auth != nil && auth.Kubernetes != nil:
+		key := strings.Join([]string{vaultNamespace, "kubernetes", auth.Kubernetes.MountPath, auth.Kubernetes.Role}, "|")
+		return getVaultSession(key, func() (*vaultSession, error) {
+			return newVaultSession(vaultNamespace, kubernetesLogin(auth.Kubernetes.Role, auth.Kubernetes.MountPath))
+		})
+	case auth != nil && auth.AppRole != nil:
+		key := strings.Join([]string{vaultNamespace, "approle", auth.AppRole.MountPath, namespace, auth.AppRole.SecretName}, "|")
+		return getVaultSession(key, func() (*vaultSession, error) {
+			return newVaultSession(vaultNamespace, appRoleLogin(kubeClient, namespace, *auth.AppRole))
+		})
+	}
+	return getVaultSession(vaultNamespace+"|default", func() (*vaultSession, error) {
+		return newVaultSession(vaultNamespace, defaultVaultLogin())
+	})
+}
+
+// kubernetesLogin logs in with the service account token of the pod.
+// ref: https://www.vaultproject.io/api/auth/kubernetes/index.html#login
+func kubernetesLogin(role, mountPath string) vaultLogin {
+	if mountPath == "" {
+		mountPath = "kubernetes"
+	}
+	return func(client *vault.Client) (*vault.SecretAuth, error) {
+		jwt, err := ioutil.ReadFile(serviceAccountTokenFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read service account token")
+		}
+		return writeVaultLogin(client, path.Join("auth", mountPath, "login"), map[string]interface{}{
+			"role": role,
+			"jwt":  string(jwt),
+		})
+	}
+}
+
+// appRoleLogin logs in with role_id and secret_id keys of a Secret. The Secret is read on each login,
+// so that a rotated secret_id is picked up.
+// ref: https://www.vaultproject.io/api/auth/approle/index.html#login-with-approle
+func appRoleLogin(kubeClient kubernetes.Interface, namespace string, auth api.VaultAppRoleAuth) vaultLogin {
+	mountPath := auth.MountPath
+	if mountPath == "" {
+		mountPath = "approle"
+	}
+	return func(client *vault.Client) (*vault.SecretAuth, error) {
+		secret, err := kubeClient.CoreV1().Secrets(namespace).Get(auth.SecretName, metav1.GetOptions{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get vault approle secret %s/%s", namespace, auth.SecretName)
+		}
+		roleID, found := secret.Data[api.VaultAppRoleID]
+		if !found {
+			return nil, errors.Errorf("secret %s/%s is missing %s", namespace, auth.SecretName, api.VaultAppRoleID)
+		}
+		data := map[string]interface{}{
+			"role_id": string(roleID),
+		}
+		if secretID, found := secret.Data[api.VaultAppRoleSecretID]; found {
+			data["secret_id"] = string(secretID)
+		}
+		return writeVaultLogin(client, path.Join("auth", mountPath, "login"), data)
+	}
+}
+
+func writeVaultLogin(client *vault.Client, loginPath string, data map[string]interface{}) (*vault.SecretAuth, error) {
+	secret, err := client.Logical().Write(loginPath, data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to login to vault via %s", loginPath)
+	}
+	if secret == nil || secret.Auth == nil || secret.Auth.ClientToken == "" {
+		return nil, errors.Errorf("vault returned no token from %s", loginPath)
+	}
+	return secret.Auth, nil
+}
@@ -0,0 +1,90 @@
+package certificate
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	vault "github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestVaultSession(t *testing.T) {
+	var logins, renewals int
+	renewLease := 3600
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var auth vault.SecretAuth
+		switch r.URL.Path {
+		case "/v1/auth/approle/login":
+			var data map[string]string
+			json.NewDecoder(r.Body).Decode(&data)
+			if data["role_id"] != "role" || data["secret_id"] != "secret" {
+				http.Error(w, "invalid credentials", http.StatusBadRequest)
+				return
+			}
+			logins++
+			auth = vault.SecretAuth{ClientToken: "login-token", LeaseDuration: 3600, Renewable: true}
+		case "/v1/auth/token/renew-self":
+			renewals++
+			auth = vault.SecretAuth{ClientToken: r.Header.Get("X-Vault-Token"), LeaseDuration: renewLease, Renewable: true}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		assert.Equal(t, "team-a", r.Header.Get(vaultNamespaceHeader))
+		json.NewEncoder(w).Encode(vault.Secret{Auth: &auth})
+	}))
+	defer server.Close()
+	os.Setenv(vault.EnvVaultAddress, server.URL)
+	defer os.Unsetenv(vault.EnvVaultAddress)
+
+	kubeClient := fake.NewSimpleClientset(&core.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "approle", Namespace: "default"},
+		Data: map[string][]byte{
+			api.VaultAppRoleID:       []byte("role"),
+			api.VaultAppRoleSecretID: []byte("secret"),
+		},
+	})
+	storage := &api.VaultStore{
+		Namespace: "team-a",
+		Auth:      &api.VaultAuth{AppRole: &api.VaultAppRoleAuth{SecretName: "approle"}},
+	}
+	session, err := vaultSessionFor(kubeClient, storage, "default")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	client, err := session.Client()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "login-token", client.Token())
+	}
+	session.Client()
+	assert.Equal(t, 1, logins)
+	assert.Equal(t, 0, renewals)
+
+	// shared by stores
+	other, _ := vaultSessionFor(kubeClient, storage, "default")
+	assert.Equal(t, session, other)
+
+	// renewed after two thirds of ttl
+	session.refreshAt = time.Now().Add(-time.Second)
+	_, err = session.Client()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, logins)
+	assert.Equal(t, 1, renewals)
+
+	// logs in again when token reaches max ttl
+	renewLease = 60
+	session.refreshAt = time.Now().Add(-time.Second)
+	_, err = session.Client()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, logins)
+	assert.Equal(t, 2, renewals)
+}
@@ -1,6 +1,7 @@
 package cmds
 
 import (
+	"os"
 	"time"
 
 	"github.com/appscode/go/log"
@@ -28,9 +29,19 @@ var (
 		MaxNumRequeues: 5,
 		NumThreads:     1,
 		ResyncPeriod:   10 * time.Minute,
+		PodName:        podName(),
 	}
 )
 
+// podName returns the name of this pod, set via downward api by the operator.
+func podName() string {
+	if name := os.Getenv("POD_NAME"); name != "" {
+		return name
+	}
+	name, _ := os.Hostname()
+	return name
+}
+
 func NewCmdHAProxyController() *cobra.Command {
 	var (
 		masterURL      string
@@ -76,6 +87,7 @@ func NewCmdHAProxyController() *cobra.Command {
 	cmd.Flags().StringVar(&opt.IngressRef.APIVersion, "ingress-api-version", opt.IngressRef.APIVersion, "API version of ingress resource")
 	cmd.Flags().StringVar(&opt.IngressRef.Name, "ingress-name", opt.IngressRef.Name, "Name of ingress resource")
 	cmd.Flags().StringVar(&opt.CertDir, "cert-dir", opt.CertDir, "Path where tls certificates are stored for HAProxy")
+	cmd.Flags().StringVar(&opt.DefaultCertificate, "default-certificate", opt.DefaultCertificate, "Default certificate of operator as <namespace>/<name>, served when Ingress does not specify its own")
 	cmd.Flags().StringVarP(&opt.CloudProvider, "cloud-provider", "c", opt.CloudProvider, "Name of cloud provider")
 
 	return cmd
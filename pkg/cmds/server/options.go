@@ -21,12 +21,14 @@ import (
 	core "k8s.io/api/core/v1"
 	kext_cs "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1beta1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
 )
 
 type OperatorOptions struct {
 	CloudProvider               string
 	CloudConfigFile             string
 	IngressClass                string
+	DefaultCertificate          string
 	EnableRBAC                  bool
 	OperatorNamespace           string
 	OperatorService             string
@@ -39,6 +41,10 @@ type OperatorOptions struct {
 	DockerRegistry              string
 	HAProxyImageTag             string
 	ExporterImageTag            string
+	LeaderElection              bool
+	LeaderElectionLeaseDuration time.Duration
+	LeaderElectionRenewDeadline time.Duration
+	LeaderElectionRetryPeriod   time.Duration
 
 	PrometheusCrdGroup string
 	PrometheusCrdKinds prom.CrdKinds
@@ -75,6 +81,11 @@ func NewOperatorOptions() *OperatorOptions {
 		ResyncPeriod:      10 * time.Minute,
 		MaxNumRequeues:    5,
 		NumThreads:        2,
+		// ref: https://github.com/kubernetes/kubernetes/blob/v1.10.0/pkg/client/leaderelectionconfig/config.go
+		LeaderElection:              true,
+		LeaderElectionLeaseDuration: 15 * time.Second,
+		LeaderElectionRenewDeadline: 10 * time.Second,
+		LeaderElectionRetryPeriod:   2 * time.Second,
 		// ref: https://github.com/kubernetes/ingress-nginx/blob/e4d53786e771cc6bdd55f180674b79f5b692e552/pkg/ingress/controller/launch.go#L252-L259
 		// High enough QPS to fit all expected use cases. QPS=0 is not set here, because client code is overriding it.
 		QPS: 1e6,
@@ -98,6 +109,7 @@ func (s *OperatorOptions) AddGoFlags(fs *flag.FlagSet) {
 	fs.StringVar(&s.CloudProvider, "cloud-provider", s.CloudProvider, "Name of cloud provider")
 	fs.StringVar(&s.CloudConfigFile, "cloud-config", s.CloudConfigFile, "The path to the cloud provider configuration file.  Empty string for no configuration file.")
 	fs.StringVar(&s.IngressClass, "ingress-class", s.IngressClass, "Ingress class handled by voyager. Unset by default. Set to voyager to only handle ingress with annotation kubernetes.io/ingress.class=voyager.")
+	fs.StringVar(&s.DefaultCertificate, "default-certificate", s.DefaultCertificate, "TLS Secret in <namespace>/<name> format used as default certificate by Ingresses that do not specify spec.defaultCertificate")
 	fs.BoolVar(&s.EnableRBAC, "rbac", s.EnableRBAC, "Enable RBAC for operator & offshoot Kubernetes objects")
 	fs.StringVar(&s.customTemplates, "custom-templates", s.customTemplates, "Glob pattern of custom HAProxy template files used to override built-in templates")
 
@@ -108,6 +120,11 @@ func (s *OperatorOptions) AddGoFlags(fs *flag.FlagSet) {
 	fs.StringVar(&s.OperatorService, "operator-service", s.OperatorService, "Name of service used to expose voyager operator")
 	fs.BoolVar(&s.RestrictToOperatorNamespace, "restrict-to-operator-namespace", s.RestrictToOperatorNamespace, "If true, voyager operator will only handle Kubernetes objects in its own namespace.")
 
+	fs.BoolVar(&s.LeaderElection, "leader-elect", s.LeaderElection, "If true, replicas of voyager operator elect a leader using a ConfigMap named <operator-service>-leader-lock in the operator namespace. Only the leader reconciles objects.")
+	fs.DurationVar(&s.LeaderElectionLeaseDuration, "leader-elect-lease-duration", s.LeaderElectionLeaseDuration, "Duration that non-leader replicas wait after the last renewal of the leader before taking over the leadership.")
+	fs.DurationVar(&s.LeaderElectionRenewDeadline, "leader-elect-renew-deadline", s.LeaderElectionRenewDeadline, "Duration that the leader retries renewing its leadership before giving it up. Must be less than the lease duration.")
+	fs.DurationVar(&s.LeaderElectionRetryPeriod, "leader-elect-retry-period", s.LeaderElectionRetryPeriod, "Duration replicas wait between tries to acquire or renew the leadership.")
+
 	fs.StringVar(&s.OpsAddress, "ops-address", s.OpsAddress, "Address to listen on for web interface and telemetry.")
 	fs.StringVar(&s.haProxyServerMetricFields, "haproxy.server-metric-fields", s.haProxyServerMetricFields, "Comma-separated list of exported server metrics. See http://cbonte.github.io/haproxy-dconv/configuration-1.5.html#9.1")
 	fs.DurationVar(&s.haProxyTimeout, "haproxy.timeout", s.haProxyTimeout, "Timeout for trying to get stats from HAProxy.")
@@ -133,10 +150,15 @@ func (s *OperatorOptions) ApplyTo(cfg *operator.OperatorConfig) error {
 	cfg.Burst = s.Burst
 	cfg.CloudConfigFile = s.CloudConfigFile
 	cfg.CloudProvider = s.CloudProvider
+	cfg.DefaultCertificate = s.DefaultCertificate
 	cfg.EnableRBAC = s.EnableRBAC
 	cfg.ExporterImage = s.ExporterImage()
 	cfg.HAProxyImage = s.HAProxyImage()
 	cfg.IngressClass = s.IngressClass
+	cfg.LeaderElection = s.LeaderElection
+	cfg.LeaderElectionLeaseDuration = s.LeaderElectionLeaseDuration
+	cfg.LeaderElectionRenewDeadline = s.LeaderElectionRenewDeadline
+	cfg.LeaderElectionRetryPeriod = s.LeaderElectionRetryPeriod
 	cfg.MaxNumRequeues = s.MaxNumRequeues
 	cfg.NumThreads = s.NumThreads
 	cfg.OperatorNamespace = s.OperatorNamespace
@@ -189,5 +211,13 @@ func (s *OperatorOptions) Validate() []error {
 	if s.IngressClass == "$INGRESS_CLASS" {
 		errs = append(errs, errors.Errorf("invalid ingress class `--ingress-class=$INGRESS_CLASS`"))
 	}
+	if s.DefaultCertificate != "" {
+		if ns, name, err := cache.SplitMetaNamespaceKey(s.DefaultCertificate); err != nil || ns == "" || name == "" {
+			errs = append(errs, errors.Errorf("invalid default certificate `--default-certificate=%s`, must be in <namespace>/<name> format", s.DefaultCertificate))
+		}
+	}
+	if s.LeaderElection && s.LeaderElectionLeaseDuration <= s.LeaderElectionRenewDeadline {
+		errs = append(errs, errors.Errorf("--leader-elect-lease-duration must be greater than --leader-elect-renew-deadline"))
+	}
 	return errs
 }
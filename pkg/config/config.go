@@ -27,10 +27,15 @@ type Config struct {
 	Burst                       int
 	CloudConfigFile             string
 	CloudProvider               string
+	DefaultCertificate          string
 	EnableRBAC                  bool
 	HAProxyImage                string
 	ExporterImage               string
 	IngressClass                string
+	LeaderElection              bool
+	LeaderElectionLeaseDuration time.Duration
+	LeaderElectionRenewDeadline time.Duration
+	LeaderElectionRetryPeriod   time.Duration
 	MaxNumRequeues              int
 	NumThreads                  int
 	OperatorNamespace           string
@@ -10,12 +10,18 @@ import (
 
 const (
 	// Certificate Events
-	EventReasonCertificateIssueFailed     = "IssueFailed"
-	EventReasonCertificateIssueSuccessful = "IssueSuccessful"
-	EventReasonCertificateInvalid         = "CertificateInvalid"
-	EventReasonCertificateMigration       = "CertificateMigration"
+	EventReasonCertificateDeleteFailed     = "DeleteFailed"
+	EventReasonCertificateIssueFailed      = "IssueFailed"
+	EventReasonCertificateIssueSuccessful  = "IssueSuccessful"
+	EventReasonCertificateInvalid          = "CertificateInvalid"
+	EventReasonCertificateMigration        = "CertificateMigration"
+	EventReasonCertificateRevokeFailed     = "RevokeFailed"
+	EventReasonCertificateRevokeSuccessful = "RevokeSuccessful"
 
 	// Ingress Events
+	EventReasonIngressCertificatePending              = "CertificatePending"
+	EventReasonIngressCertificateReconcileFailed      = "CertificateReconcileFailed"
+	EventReasonIngressCertificateReconcileSuccessful  = "CertificateReconcileSuccessful"
 	EventReasonIngressConfigMapReconcileFailed        = "ConfigMapReconcileFailed"
 	EventReasonIngressConfigMapReconcileSuccessful    = "ConfigMapReconcileSuccessful"
 	EventReasonIngressDeploymentReconcileFailed       = "DeploymentReconcileFailed"
@@ -25,6 +31,8 @@ const (
 	EventReasonIngressInvalid                         = "IngressInvalid"
 	EventReasonIngressMonitorAgentReconcileFailed     = "MonitorAgentReconcileFailed"
 	EventReasonIngressMonitorAgentReconcileSuccessful = "MonitorAgentReconcileSuccessful"
+	EventReasonIngressOCSPFetchFailed                 = "OCSPFetchFailed"
+	EventReasonIngressOCSPUpdateFailed                = "OCSPUpdateFailed"
 	EventReasonIngressRBACFailed                      = "RBACFailed"
 	EventReasonIngressRBACSuccessful                  = "RBACSuccessful"
 	EventReasonIngressServiceReconcileFailed          = "ServiceReconcileFailed"
@@ -34,6 +42,8 @@ const (
 	EventReasonIngressStatsServiceReconcileFailed     = "StatsServiceReconcileFailed"
 	EventReasonIngressStatsServiceReconcileSuccessful = "StatsServiceReconcileSuccessful"
 	EventReasonIngressTLSMountFailed                  = "TLSMountFailed"
+	EventReasonIngressTLSSecretExpiring               = "TLSSecretExpiring"
+	EventReasonIngressTLSSecretInvalid                = "TLSSecretInvalid"
 	EventReasonBackendInvalid                         = "BackendInvalid"
 )
 
@@ -9,6 +9,10 @@ import (
 	api "github.com/appscode/voyager/apis/voyager/v1beta1"
 )
 
+// DefaultCertificateFile is the file in tls directory where haproxy-controller projects the default
+// certificate. Secret names can't contain '_', so it never collides with the file of a Secret.
+const DefaultCertificateFile = "default_certificate.pem"
+
 type TemplateData struct {
 	*SharedInfo
 	TimeoutDefaults []TimeoutConfig
@@ -51,6 +55,7 @@ type SharedInfo struct {
 	MaxConnections        int
 	UseNodePort           bool
 	Limit                 *Limit
+	DefaultCertFile       string
 }
 
 type CORSConfig struct {
@@ -76,10 +81,12 @@ type HTTPService struct {
 	NodePort       int32
 	NodePortFor443 int32
 	OffloadSSL     bool
-	FrontendRules  []string
-	BasicAuth      *BasicAuth
-	TLSAuth        *TLSAuth
-	Hosts          []*HTTPHost
+	// ACMEChallenge is true if this frontend routes http-01 challenge requests to operator
+	ACMEChallenge bool
+	FrontendRules []string
+	BasicAuth     *BasicAuth
+	TLSAuth       *TLSAuth
+	Hosts         []*HTTPHost
 }
 
 func (svc HTTPService) RedirectSSL() bool {
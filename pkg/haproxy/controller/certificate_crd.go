@@ -48,6 +48,11 @@ func (c *Controller) isCertificateUsedInIngress(s *api.Certificate) bool {
 			return true
 		}
 	}
+	if ref := r.Spec.DefaultCertificate; ref != nil {
+		if s.Name == ref.Name && strings.EqualFold(ref.Kind, api.ResourceKindCertificate) {
+			return true
+		}
+	}
 	return false
 }
 
@@ -74,13 +79,20 @@ func (c *Controller) getCertificate(name string) (*api.Certificate, error) {
 	return obj.(*api.Certificate), nil
 }
 
-func (c *Controller) projectCertificate(r *api.Certificate, projections map[string]ioutilz.FileProjection) error {
+func (c *Controller) projectCertificate(r *api.Certificate, certFile string, projections map[string]ioutilz.FileProjection) error {
 	pemCrt, pemKey, err := c.store.Get(r)
 	if err != nil {
 		return err
 	}
-	if pemCrt != nil && pemKey != nil {
-		projections["tls/"+r.SecretName()+".pem"] = ioutilz.FileProjection{Mode: 0755, Data: certificateToPEMData(pemCrt, pemKey)}
+	if pemCrt == nil || pemKey == nil {
+		return nil
 	}
-	return nil
+	var ecdsaCrt, ecdsaKey []byte
+	if r.Spec.DualKey {
+		ecdsaCrt, ecdsaKey, err = c.store.GetECDSA(r)
+		if err != nil {
+			return err
+		}
+	}
+	return projectCertBundle(certFile, pemCrt, pemKey, ecdsaCrt, ecdsaKey, projections)
 }
@@ -3,10 +3,13 @@ package controller
 import (
 	"bytes"
 	"strings"
+	"sync"
 	"time"
 
 	ioutilz "github.com/appscode/go/ioutil"
+	"github.com/appscode/kutil/discovery"
 	"github.com/appscode/kutil/tools/queue"
+	"github.com/appscode/voyager/apis/voyager"
 	api "github.com/appscode/voyager/apis/voyager/v1beta1"
 	cs "github.com/appscode/voyager/client/clientset/versioned"
 	voyagerinformers "github.com/appscode/voyager/client/informers/externalversions"
@@ -15,8 +18,10 @@ import (
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
 	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
@@ -34,6 +39,10 @@ type Options struct {
 	MaxNumRequeues int
 	NumThreads     int
 	ResyncPeriod   time.Duration
+	// <namespace>/<name> of the default certificate of operator, if served by this Ingress
+	DefaultCertificate string
+	// name of the pod, reported in status.pods of the Ingress
+	PodName string
 }
 
 func (opts Options) UsesEngress() bool {
@@ -67,6 +76,18 @@ type Controller struct {
 
 	crtQueue    *queue.Worker
 	crtInformer cache.SharedIndexInformer
+
+	// hash of last written certificates, used to decide haproxy reload
+	certHash string
+	// hash of haproxy.cfg haproxy is running with, reported via ConfigApplied condition
+	configHash string
+
+	ocspLock    sync.Mutex
+	staples     map[string]*ocspStaple
+	ocspTrigger chan struct{}
+
+	placeholderLock sync.Mutex
+	placeholders    map[string]*placeholderCert
 }
 
 func New(client kubernetes.Interface, voyagerClient cs.Interface, opt Options) *Controller {
@@ -77,6 +98,7 @@ func New(client kubernetes.Interface, voyagerClient cs.Interface, opt Options) *
 		voyagerInformerFactory: voyagerinformers.NewFilteredSharedInformerFactory(voyagerClient, opt.ResyncPeriod, opt.IngressRef.Namespace, nil),
 		options:                opt,
 		recorder:               eventer.NewEventRecorder(client, "haproxy-controller"),
+		ocspTrigger:            make(chan struct{}, 1),
 	}
 }
 
@@ -97,6 +119,12 @@ func (c *Controller) Setup() (err error) {
 		return
 	}
 
+	// operator enables status subresource of Ingresses on Kubernetes 1.11 or later
+	voyager.EnableStatusSubresource, err = discovery.CheckAPIVersion(c.k8sClient.Discovery(), ">= 1.11")
+	if err != nil {
+		return
+	}
+
 	if c.options.IngressRef.APIVersion == api.SchemeGroupVersion.String() {
 		c.initIngressCRDWatcher()
 	} else {
@@ -195,6 +223,37 @@ func (c *Controller) initTLSCache(ing *api.Ingress) error {
 				return err
 			}
 		}
+		if tls.Auth != nil {
+			stls, err := c.k8sClient.CoreV1().Secrets(c.options.IngressRef.Namespace).Get(tls.Auth.SecretName, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			err = c.secretInformer.GetIndexer().Add(stls)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// a placeholder is served for the default certificate until it is available
+	if ref := ing.Spec.DefaultCertificate; ref != nil {
+		if strings.EqualFold(ref.Kind, api.ResourceKindCertificate) {
+			crd, err := c.VoyagerClient.VoyagerV1beta1().Certificates(c.options.IngressRef.Namespace).Get(ref.Name, metav1.GetOptions{})
+			if err == nil {
+				err = c.crtInformer.GetIndexer().Add(crd)
+			}
+			if err != nil && !kerr.IsNotFound(err) {
+				return err
+			}
+		} else {
+			sc, err := c.k8sClient.CoreV1().Secrets(c.options.IngressRef.Namespace).Get(ref.Name, metav1.GetOptions{})
+			if err == nil {
+				err = c.secretInformer.GetIndexer().Add(sc)
+			}
+			if err != nil && !kerr.IsNotFound(err) {
+				return err
+			}
+		}
 	}
 
 	if name := ing.AuthTLSSecret(); name != "" {
@@ -266,6 +325,16 @@ func (c *Controller) Run(stopCh chan struct{}) {
 	c.getIngressWorker().Run(stopCh)
 	c.crtQueue.Run(stopCh)
 
+	go c.runOCSPStapler(stopCh)
+
+	// default certificate of operator is in another namespace, so it is not watched. Operator updates
+	// the hash of it in ConfigMap when it changes, and it is read again periodically as a fallback.
+	if c.options.DefaultCertificate != "" && c.options.ResyncPeriod > 0 {
+		go wait.Until(func() {
+			c.getIngressWorker().GetQueue().Add(c.options.IngressRef.Namespace + "/" + c.options.IngressRef.Name)
+		}, c.options.ResyncPeriod, stopCh)
+	}
+
 	ticker := time.NewTicker(30 * time.Second)
 	defer ticker.Stop()
 	go func() {
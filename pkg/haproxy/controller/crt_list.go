@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"path/filepath"
+	"strings"
+
+	ioutilz "github.com/appscode/go/ioutil"
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/cert"
+)
+
+// crtListFile is the name of the crt-list projected into CertDir. HTTP frontends
+// that offload SSL bind with `crt-list <CertDir>/crt-list`.
+const crtListFile = "crt-list"
+
+func tlsCertFile(name string) string {
+	return "tls/" + name + ".pem"
+}
+
+// projectCertBundle projects certFile. If an ECDSA certificate is given, certFile is projected as
+// HAProxy multi-cert bundle, ie, "<certFile>.rsa" and "<certFile>.ecdsa". HAProxy loads both for
+// `crt <certFile>` and serves the one supported by the client.
+// ref: https://cbonte.github.io/haproxy-dconv/1.8/configuration.html#5.1-crt
+func projectCertBundle(certFile string, pemCrt, pemKey, ecdsaCrt, ecdsaKey []byte, projections map[string]ioutilz.FileProjection) error {
+	if ecdsaCrt == nil {
+		projections[certFile] = ioutilz.FileProjection{Mode: 0755, Data: certificateToPEMData(pemCrt, pemKey)}
+		return nil
+	}
+
+	if key, err := cert.ParsePrivateKeyPEM(pemKey); err != nil {
+		return errors.Wrapf(err, "failed to parse private key for %s", certFile)
+	} else if _, ok := key.(*rsa.PrivateKey); !ok {
+		return errors.Errorf("%s must be a RSA key for %s, since an ECDSA certificate is also provided", core.TLSPrivateKeyKey, certFile)
+	}
+	if key, err := cert.ParsePrivateKeyPEM(ecdsaKey); err != nil {
+		return errors.Wrapf(err, "failed to parse ECDSA private key for %s", certFile)
+	} else if _, ok := key.(*ecdsa.PrivateKey); !ok {
+		return errors.Errorf("%s must be an ECDSA key for %s", api.TLSECDSAPrivateKeyKey, certFile)
+	}
+	projections[certFile+".rsa"] = ioutilz.FileProjection{Mode: 0755, Data: certificateToPEMData(pemCrt, pemKey)}
+	projections[certFile+".ecdsa"] = ioutilz.FileProjection{Mode: 0755, Data: certificateToPEMData(ecdsaCrt, ecdsaKey)}
+	return nil
+}
+
+// isCertProjected returns true if certFile is projected as a single certificate or as a multi-cert bundle.
+func isCertProjected(certFile string, projections map[string]ioutilz.FileProjection) bool {
+	_, found := projections[certFile]
+	_, foundBundle := projections[certFile+".rsa"]
+	return found || foundBundle
+}
+
+// crtListEntry returns the crt-list line for a spec.tls entry.
+// ref: https://cbonte.github.io/haproxy-dconv/1.8/configuration.html#5.1-crt-list
+func (c *Controller) crtListEntry(tls api.IngressTLS, certFile string, projections map[string]ioutilz.FileProjection) string {
+	certDir := strings.TrimSuffix(c.options.CertDir, "/")
+
+	var opts []string
+	if len(tls.ALPN) > 0 {
+		opts = append(opts, "alpn "+strings.Join(tls.ALPN, ","))
+	}
+	if tls.Ciphers != "" {
+		opts = append(opts, "ciphers "+tls.Ciphers)
+	}
+	if tls.Auth != nil {
+		opts = append(opts, "ca-file "+filepath.Join(certDir, "ca", tls.Auth.SecretName+"-ca.crt"))
+		if crl := "ca/" + tls.Auth.SecretName + "-crl.pem"; projections[crl].Data != nil {
+			opts = append(opts, "crl-file "+filepath.Join(certDir, crl))
+		}
+		verify := tls.Auth.VerifyClient
+		if verify == "" {
+			verify = api.TLSAuthVerifyRequired
+		}
+		opts = append(opts, "verify "+string(verify))
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(filepath.Join(certDir, certFile))
+	if len(opts) > 0 {
+		buf.WriteString(" [")
+		buf.WriteString(strings.Join(opts, " "))
+		buf.WriteString("]")
+	}
+	for _, host := range tls.Hosts {
+		buf.WriteRune(' ')
+		buf.WriteString(host)
+	}
+	return buf.String()
+}
@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	ioutilz "github.com/appscode/go/ioutil"
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/util/cert"
+)
+
+func TestProjectCertBundle(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaCrt, err := cert.NewSelfSignedCACert(cert.Config{CommonName: "example.com"}, rsaKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsaCrt := newCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}, rsaCrt, ecdsaKey.Public(), rsaKey)
+	ecdsaDER, err := x509.MarshalECPrivateKey(ecdsaKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsaKeyPEM := pem.EncodeToMemory(&pem.Block{Type: cert.ECPrivateKeyBlockType, Bytes: ecdsaDER})
+
+	projections := map[string]ioutilz.FileProjection{}
+	err = projectCertBundle("tls/single.pem", cert.EncodeCertPEM(rsaCrt), cert.EncodePrivateKeyPEM(rsaKey), nil, nil, projections)
+	assert.NoError(t, err)
+	assert.Contains(t, projections, "tls/single.pem")
+	assert.True(t, isCertProjected("tls/single.pem", projections))
+
+	err = projectCertBundle("tls/dual.pem", cert.EncodeCertPEM(rsaCrt), cert.EncodePrivateKeyPEM(rsaKey), cert.EncodeCertPEM(ecdsaCrt), ecdsaKeyPEM, projections)
+	assert.NoError(t, err)
+	assert.Contains(t, projections, "tls/dual.pem.rsa")
+	assert.Contains(t, projections, "tls/dual.pem.ecdsa")
+	assert.NotContains(t, projections, "tls/dual.pem")
+	assert.True(t, isCertProjected("tls/dual.pem", projections))
+
+	// key types are swapped
+	err = projectCertBundle("tls/invalid.pem", cert.EncodeCertPEM(ecdsaCrt), ecdsaKeyPEM, cert.EncodeCertPEM(rsaCrt), cert.EncodePrivateKeyPEM(rsaKey), projections)
+	assert.Error(t, err)
+	assert.False(t, isCertProjected("tls/invalid.pem", projections))
+}
+
+func TestCrtListEntry(t *testing.T) {
+	c := &Controller{options: Options{CertDir: "/etc/ssl/private/haproxy/"}}
+	projections := map[string]ioutilz.FileProjection{
+		"ca/client-ca-crl.pem": {Data: []byte("crl")},
+	}
+
+	assert.Equal(t,
+		"/etc/ssl/private/haproxy/tls/one.pem one.example.com",
+		c.crtListEntry(api.IngressTLS{Hosts: []string{"one.example.com"}}, "tls/one.pem", projections),
+	)
+	assert.Equal(t,
+		"/etc/ssl/private/haproxy/tls/two.pem [alpn h2,http/1.1 ciphers ECDHE-RSA-AES128-GCM-SHA256 ca-file /etc/ssl/private/haproxy/ca/client-ca-ca.crt crl-file /etc/ssl/private/haproxy/ca/client-ca-crl.pem verify required] two.example.com *.two.example.com",
+		c.crtListEntry(api.IngressTLS{
+			Hosts:   []string{"two.example.com", "*.two.example.com"},
+			ALPN:    []string{"h2", "http/1.1"},
+			Ciphers: "ECDHE-RSA-AES128-GCM-SHA256",
+			Auth:    &api.TLSAuth{SecretName: "client-ca"},
+		}, "tls/two.pem", projections),
+	)
+	// crl-file is set only if the auth secret has ca.crl
+	assert.Equal(t,
+		"/etc/ssl/private/haproxy/tls/three.pem [ca-file /etc/ssl/private/haproxy/ca/other-ca-ca.crt verify optional] three.example.com",
+		c.crtListEntry(api.IngressTLS{
+			Hosts: []string{"three.example.com"},
+			Auth:  &api.TLSAuth{SecretName: "other-ca", VerifyClient: api.TLSAuthVerifyOptional},
+		}, "tls/three.pem", projections),
+	)
+}
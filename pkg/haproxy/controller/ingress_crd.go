@@ -11,10 +11,13 @@ import (
 	cs "github.com/appscode/voyager/client/clientset/versioned"
 	voyager_informers "github.com/appscode/voyager/client/informers/externalversions/voyager/v1beta1"
 	"github.com/appscode/voyager/pkg/eventer"
+	hpi "github.com/appscode/voyager/pkg/haproxy/api"
+	"github.com/appscode/voyager/pkg/haproxy/template"
 	"github.com/golang/glog"
 	core "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -77,11 +80,14 @@ func (c *Controller) syncIngressCRD(key string) error {
 		// Below we will warm up our cache with a Ingress, so that we will see a delete for one d
 		fmt.Printf("Ingress %s does not exist anymore\n", key)
 	} else {
-		d := obj.(*api.Ingress)
+		d := obj.(*api.Ingress).DeepCopy()
 		fmt.Printf("Sync/Add/Update for Ingress %s\n", d.GetName())
 		d.Migrate()
 
 		err = c.mountIngress(d)
+		if e2 := c.updateConfigAppliedCondition(d, err); e2 != nil {
+			glog.Errorf("failed to update status of Ingress %s. Reason: %v", key, e2)
+		}
 		if err != nil {
 			c.recorder.Event(
 				d.ObjectReference(),
@@ -149,26 +155,56 @@ func (c *Controller) projectCerts(ing *api.Ingress, projections map[string]iouti
 		return err
 	}
 
+	var crtList []string
 	for _, tls := range ing.Spec.TLS {
+		var certFile string
 		if strings.EqualFold(tls.Ref.Kind, api.ResourceKindCertificate) {
 			r, err := c.getCertificate(tls.Ref.Name)
-			if err != nil {
+			if kerr.IsNotFound(err) {
+				// not created yet, ie, by operator for acme-issuer annotation
+				r = &api.Certificate{ObjectMeta: metav1.ObjectMeta{Name: tls.Ref.Name}}
+			} else if err != nil {
 				return err
-			}
-			err = c.projectCertificate(r, projections)
-			if err != nil {
+			} else if err = c.projectCertificate(r, tlsCertFile(r.SecretName()), projections); err != nil {
 				return err
 			}
+			certFile = tlsCertFile(r.SecretName())
+			if !isCertProjected(certFile, projections) {
+				// serve a placeholder until the certificate is issued, instead of leaving its hosts without tls
+				if err = c.projectPlaceholderCert(certFile, tls.Hosts, projections); err != nil {
+					return err
+				}
+			}
 		} else {
+			certFile = tlsCertFile(tls.Ref.Name)
 			r, err := c.getSecret(tls.Ref.Name)
+			if kerr.IsNotFound(err) && ing.ACMEIssuerSecret() != "" {
+				// not issued yet by the Certificate operator created for acme-issuer annotation. Its hosts
+				// must be served until then, since the HTTP-01 challenge is answered through this Ingress.
+				err = c.projectPlaceholderCert(certFile, tls.Hosts, projections)
+			} else if err == nil {
+				err = c.projectTLSSecret(r, certFile, projections)
+			}
 			if err != nil {
 				return err
 			}
-			err = c.projectTLSSecret(r, projections)
+		}
+		if tls.Auth != nil {
+			r, err := c.getSecret(tls.Auth.SecretName)
+			if err != nil {
+				return err
+			}
+			err = c.projectAuthSecret(r, projections)
 			if err != nil {
 				return err
 			}
 		}
+		crtList = append(crtList, c.crtListEntry(tls, certFile, projections))
+	}
+	projections[crtListFile] = ioutilz.FileProjection{Mode: 0755, Data: []byte(strings.Join(crtList, "\n") + "\n")}
+
+	if err = c.projectDefaultCertificate(ing, projections); err != nil {
+		return err
 	}
 
 	if name := ing.AuthTLSSecret(); name != "" {
@@ -197,6 +233,50 @@ func (c *Controller) projectCerts(ing *api.Ingress, projections map[string]iouti
 	return nil
 }
 
+// projectDefaultCertificate projects the certificate referred by spec.defaultCertificate. Otherwise, the default
+// certificate of operator is projected, if operator asked to serve it. A placeholder is served until the
+// certificate is available, since HAProxy does not start if the file is missing.
+func (c *Controller) projectDefaultCertificate(ing *api.Ingress, projections map[string]ioutilz.FileProjection) error {
+	certFile := "tls/" + hpi.DefaultCertificateFile
+
+	if ref := ing.Spec.DefaultCertificate; ref != nil {
+		if strings.EqualFold(ref.Kind, api.ResourceKindCertificate) {
+			r, err := c.getCertificate(ref.Name)
+			if err == nil {
+				err = c.projectCertificate(r, certFile, projections)
+			}
+			if err != nil && !kerr.IsNotFound(err) {
+				return err
+			}
+		} else {
+			r, err := c.getSecret(ref.Name)
+			if err == nil {
+				err = c.projectTLSSecret(r, certFile, projections)
+			}
+			if err != nil && !kerr.IsNotFound(err) {
+				return err
+			}
+		}
+	} else if c.options.DefaultCertificate != "" {
+		r, err := c.getDefaultCertificate()
+		if err == nil {
+			err = c.projectTLSSecret(r, certFile, projections)
+		}
+		if kerr.IsNotFound(err) {
+			glog.Warningf("default certificate %s not found", c.options.DefaultCertificate)
+		} else if err != nil {
+			return err
+		}
+	} else {
+		return nil
+	}
+
+	if !isCertProjected(certFile, projections) {
+		return c.projectPlaceholderCert(certFile, nil, projections)
+	}
+	return nil
+}
+
 func (c *Controller) mountIngress(ing *api.Ingress) error {
 	cfgProjections := map[string]ioutilz.FileProjection{}
 	err := c.projectConfig(ing, cfgProjections)
@@ -216,6 +296,11 @@ func (c *Controller) mountIngress(ing *api.Ingress) error {
 	if err != nil {
 		return err
 	}
+	if ing.OCSPStapling() {
+		c.projectOCSPResponses(certProjections)
+	} else {
+		c.resetOCSPResponses()
+	}
 	certChanged, err := c.certWriter.Write(certProjections)
 	if err != nil {
 		return err
@@ -224,8 +309,16 @@ func (c *Controller) mountIngress(ing *api.Ingress) error {
 		incCertChangedCounter()
 	}
 
-	if cfgChanged || certChanged {
-		return runCmd()
+	// refreshed OCSP responses are already applied via runtime api, so those do not need reload
+	certHash := certProjectionsHash(certProjections)
+	reload := cfgChanged || certHash != c.certHash
+	c.certHash = certHash
+	if reload {
+		if err = runCmd(); err != nil {
+			c.certHash = "" // retry reload on next sync
+			return err
+		}
 	}
+	c.configHash = template.ConfigHash(string(cfgProjections["haproxy.cfg"].Data))
 	return nil
 }
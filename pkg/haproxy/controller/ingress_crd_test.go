@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"testing"
+
+	ioutilz "github.com/appscode/go/ioutil"
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/util/cert"
+)
+
+func TestProjectCertsSecretRef(t *testing.T) {
+	factory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+	c := &Controller{
+		options:        Options{IngressRef: core.ObjectReference{Namespace: "default", Name: "test-ingress"}},
+		cfgInformer:    factory.Core().V1().ConfigMaps().Informer(),
+		secretInformer: factory.Core().V1().Secrets().Informer(),
+	}
+	assert.NoError(t, c.cfgInformer.GetIndexer().Add(&core.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: api.VoyagerPrefix + "test-ingress", Namespace: "default"},
+		Data:       map[string]string{"haproxy.cfg": "global"},
+	}))
+
+	ing := &api.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-ingress",
+			Namespace:   "default",
+			Annotations: map[string]string{api.ACMEIssuerSecret: "acme-account"},
+		},
+		Spec: api.IngressSpec{
+			TLS: []api.IngressTLS{{
+				Hosts: []string{"one.example.com", "two.example.com"},
+				Ref:   &api.LocalTypedReference{Kind: "Secret", Name: "example-tls"},
+			}},
+		},
+	}
+
+	// placeholder is served until the secret is issued for acme-issuer annotation
+	projections := map[string]ioutilz.FileProjection{}
+	if assert.NoError(t, c.projectCerts(ing, projections)) {
+		assert.True(t, c.isPlaceholderCert("tls/example-tls.pem", projections["tls/example-tls.pem"].Data))
+		assert.Equal(t, "tls/example-tls.pem one.example.com two.example.com\n", string(projections[crtListFile].Data))
+	}
+
+	// otherwise the secret must exist
+	delete(ing.Annotations, api.ACMEIssuerSecret)
+	err := c.projectCerts(ing, map[string]ioutilz.FileProjection{})
+	assert.True(t, kerr.IsNotFound(err))
+
+	key, err := cert.MakeEllipticPrivateKeyPEM()
+	if err != nil {
+		t.Fatal(err)
+	}
+	crt, _, err := cert.GenerateSelfSignedCertKey("one.example.com", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, c.secretInformer.GetIndexer().Add(&core.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-tls", Namespace: "default"},
+		Data:       map[string][]byte{core.TLSCertKey: crt, core.TLSPrivateKeyKey: key},
+	}))
+	projections = map[string]ioutilz.FileProjection{}
+	if assert.NoError(t, c.projectCerts(ing, projections)) {
+		assert.Contains(t, string(projections["tls/example-tls.pem"].Data), string(crt))
+	}
+}
@@ -0,0 +1,271 @@
+package controller
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	ioutilz "github.com/appscode/go/ioutil"
+	"github.com/appscode/voyager/pkg/eventer"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ocsp"
+	core "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// ocspRefreshInterval is how often stapled responses are checked for refresh.
+	// Responses without nextUpdate are also refreshed at this interval.
+	ocspRefreshInterval = time.Hour
+	ocspRequestTimeout  = 30 * time.Second
+)
+
+// ocspStaple holds the OCSP response stapled for a projected certificate.
+type ocspStaple struct {
+	pem        []byte // projected certificate chain and key
+	response   []byte // DER encoded OCSP response
+	thisUpdate time.Time
+	nextUpdate time.Time
+}
+
+func (s *ocspStaple) needsRefresh(now time.Time) bool {
+	if s.response == nil {
+		return true
+	}
+	if s.nextUpdate.IsZero() {
+		return now.After(s.thisUpdate.Add(ocspRefreshInterval))
+	}
+	// refresh halfway through the validity period, like most OCSP stapling implementations
+	return now.After(s.thisUpdate.Add(s.nextUpdate.Sub(s.thisUpdate) / 2))
+}
+
+// projectOCSPResponses adds the cached OCSP response of each projected certificate
+// as "<cert>.pem.ocsp" (or "<cert>.pem.{rsa,ecdsa}.ocsp" for multi-cert bundles),
+// which HAProxy loads along with the certificate.
+func (c *Controller) projectOCSPResponses(projections map[string]ioutilz.FileProjection) {
+	c.ocspLock.Lock()
+	defer c.ocspLock.Unlock()
+
+	staples := map[string]*ocspStaple{}
+	for path, p := range projections {
+		if !strings.HasPrefix(path, "tls/") ||
+			!(strings.HasSuffix(path, ".pem") || strings.HasSuffix(path, ".pem.rsa") || strings.HasSuffix(path, ".pem.ecdsa")) {
+			continue
+		}
+		if c.isPlaceholderCert(path, p.Data) {
+			// self-signed, there is no OCSP responder to ask
+			continue
+		}
+		s, found := c.staples[path]
+		if !found || !bytes.Equal(s.pem, p.Data) {
+			// new or renewed certificate, cached response does not apply anymore
+			s = &ocspStaple{pem: p.Data}
+		}
+		staples[path] = s
+		if s.response != nil {
+			projections[path+".ocsp"] = ioutilz.FileProjection{Mode: 0755, Data: s.response}
+		}
+	}
+	c.staples = staples
+
+	select {
+	case c.ocspTrigger <- struct{}{}:
+	default:
+	}
+}
+
+func (c *Controller) resetOCSPResponses() {
+	c.ocspLock.Lock()
+	defer c.ocspLock.Unlock()
+	c.staples = nil
+}
+
+func (c *Controller) runOCSPStapler(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(ocspRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+		case <-c.ocspTrigger:
+		}
+		c.refreshOCSPResponses()
+	}
+}
+
+func (c *Controller) refreshOCSPResponses() {
+	ing, err := c.getIngress()
+	if err != nil {
+		glog.Errorln(err)
+		return
+	}
+	if !ing.OCSPStapling() {
+		return
+	}
+
+	now := time.Now()
+	pending := map[string][]byte{}
+	c.ocspLock.Lock()
+	for path, s := range c.staples {
+		if s.needsRefresh(now) {
+			pending[path] = s.pem
+		}
+	}
+	c.ocspLock.Unlock()
+
+	updated := false
+	for path, pemData := range pending {
+		resp, der, err := fetchOCSPResponse(pemData)
+		if err != nil {
+			c.recorder.Eventf(
+				ing.ObjectReference(),
+				core.EventTypeWarning,
+				eventer.EventReasonIngressOCSPFetchFailed,
+				"Failed to fetch OCSP response for %s, reason: %s", path, err,
+			)
+			continue
+		}
+		if resp.Status == ocsp.Revoked {
+			c.recorder.Eventf(
+				ing.ObjectReference(),
+				core.EventTypeWarning,
+				eventer.EventReasonIngressOCSPFetchFailed,
+				"Certificate %s is revoked by issuer at %s", path, resp.RevokedAt,
+			)
+		}
+
+		c.ocspLock.Lock()
+		s, found := c.staples[path]
+		if !found || !bytes.Equal(s.pem, pemData) {
+			// certificate changed while fetching, drop this response
+			c.ocspLock.Unlock()
+			continue
+		}
+		loaded := s.response != nil
+		s.response = der
+		s.thisUpdate = resp.ThisUpdate
+		s.nextUpdate = resp.NextUpdate
+		c.ocspLock.Unlock()
+		updated = true
+
+		// HAProxy only accepts runtime updates for certificates loaded with an OCSP response.
+		// Others pick up the new .ocsp file on reload.
+		if loaded {
+			if err := setOCSPResponse(der); err != nil {
+				c.recorder.Eventf(
+					ing.ObjectReference(),
+					core.EventTypeWarning,
+					eventer.EventReasonIngressOCSPUpdateFailed,
+					"Failed to update OCSP response for %s, reason: %s", path, err,
+				)
+			}
+		}
+	}
+
+	if updated {
+		// write .ocsp files, so that responses survive haproxy reloads
+		key, err := cache.MetaNamespaceKeyFunc(cache.ExplicitKey(c.options.IngressRef.Namespace + "/" + c.options.IngressRef.Name))
+		if err != nil {
+			glog.Errorln(err)
+			return
+		}
+		c.getIngressWorker().GetQueue().Add(key)
+	}
+}
+
+// fetchOCSPResponse queries the OCSP responder of the leaf certificate in pemData.
+// The issuer certificate must follow the leaf certificate in pemData.
+func fetchOCSPResponse(pemData []byte) (*ocsp.Response, []byte, error) {
+	var certs []*x509.Certificate
+	for block, rest := pem.Decode(pemData); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to parse certificate")
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, nil, errors.New("no certificate found")
+	}
+	if len(certs) == 1 {
+		return nil, nil, errors.New("issuer certificate not found in certificate chain")
+	}
+	leaf, issuer := certs[0], certs[1]
+	if len(leaf.OCSPServer) == 0 {
+		return nil, nil, errors.New("certificate does not specify any OCSP responder")
+	}
+
+	req, err := ocsp.CreateRequest(leaf, issuer, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create OCSP request")
+	}
+	client := &http.Client{Timeout: ocspRequestTimeout}
+	r, err := client.Post(leaf.OCSPServer[0], "application/ocsp-request", bytes.NewReader(req))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer r.Body.Close()
+	if r.StatusCode != http.StatusOK {
+		return nil, nil, errors.Errorf("OCSP responder %s returned status %s", leaf.OCSPServer[0], r.Status)
+	}
+	der, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := ocsp.ParseResponseForCert(der, leaf, issuer)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse OCSP response")
+	}
+	if resp.Status == ocsp.Unknown {
+		return nil, nil, errors.Errorf("OCSP responder %s does not know the certificate", leaf.OCSPServer[0])
+	}
+	return resp, der, nil
+}
+
+// setOCSPResponse updates the stapled OCSP response in running haproxy.
+// ref: https://cbonte.github.io/haproxy-dconv/1.8/management.html#9.3-set%20ssl%20ocsp-response
+func setOCSPResponse(der []byte) error {
+	out, err := runHAProxyCommand(fmt.Sprintf("set ssl ocsp-response %s", base64.StdEncoding.EncodeToString(der)))
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(out, "OCSP Response updated") {
+		return errors.New(out)
+	}
+	return nil
+}
+
+// certProjectionsHash hashes the projected certificates. Content of .ocsp files are
+// ignored, since those are updated in running haproxy without reload.
+func certProjectionsHash(projections map[string]ioutilz.FileProjection) string {
+	keys := make([]string, 0, len(projections))
+	for k := range projections {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := md5.New()
+	for _, k := range keys {
+		h.Write([]byte(k))
+		if !strings.HasSuffix(k, ".ocsp") {
+			h.Write(projections[k].Data)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
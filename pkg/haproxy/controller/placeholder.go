@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	ioutilz "github.com/appscode/go/ioutil"
+	"github.com/pkg/errors"
+)
+
+// placeholderValidity is the lifetime of placeholder certificates. They are replaced as soon as
+// the real certificate is issued, so they only need to outlive a slow issuance.
+const placeholderValidity = 90 * 24 * time.Hour
+
+// placeholderCert is a self-signed certificate served for the hosts of a Certificate
+// that is not issued yet.
+type placeholderCert struct {
+	hosts string // sorted hosts, comma separated
+	pem   []byte // certificate and private key
+}
+
+// projectPlaceholderCert projects a self-signed certificate for hosts as certFile. Certificates are
+// generated once per certFile and hosts, so that haproxy is not reloaded on each sync.
+func (c *Controller) projectPlaceholderCert(certFile string, hosts []string, projections map[string]ioutilz.FileProjection) error {
+	sorted := append([]string(nil), hosts...)
+	sort.Strings(sorted)
+	key := strings.Join(sorted, ",")
+
+	c.placeholderLock.Lock()
+	defer c.placeholderLock.Unlock()
+
+	p, found := c.placeholders[certFile]
+	if !found || p.hosts != key {
+		data, err := newPlaceholderCert(sorted)
+		if err != nil {
+			return errors.Wrapf(err, "failed to generate placeholder certificate for %s", certFile)
+		}
+		p = &placeholderCert{hosts: key, pem: data}
+		if c.placeholders == nil {
+			c.placeholders = map[string]*placeholderCert{}
+		}
+		c.placeholders[certFile] = p
+	}
+	projections[certFile] = ioutilz.FileProjection{Mode: 0755, Data: p.pem}
+	return nil
+}
+
+// isPlaceholderCert returns true if data is the placeholder certificate projected as certFile.
+func (c *Controller) isPlaceholderCert(certFile string, data []byte) bool {
+	c.placeholderLock.Lock()
+	defer c.placeholderLock.Unlock()
+
+	p, found := c.placeholders[certFile]
+	return found && bytes.Equal(p.pem, data)
+}
+
+// newPlaceholderCert returns a self-signed certificate and its private key in PEM format.
+func newPlaceholderCert(hosts []string) ([]byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	cn := "voyager-placeholder"
+	if len(hosts) > 0 {
+		cn = hosts[0]
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: cn, Organization: []string{"Voyager placeholder"}},
+		DNSNames:              hosts,
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(placeholderValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return certificateToPEMData(
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	), nil
+}
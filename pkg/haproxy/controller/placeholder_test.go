@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"testing"
+
+	ioutilz "github.com/appscode/go/ioutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/util/cert"
+)
+
+func TestProjectPlaceholderCert(t *testing.T) {
+	c := &Controller{}
+	projections := map[string]ioutilz.FileProjection{}
+	if !assert.NoError(t, c.projectPlaceholderCert("tls/tls-example.pem", []string{"www.example.com", "example.com"}, projections)) {
+		return
+	}
+	data := projections["tls/tls-example.pem"].Data
+	certs, err := cert.ParseCertsPEM(data)
+	if assert.NoError(t, err) {
+		assert.NoError(t, certs[0].VerifyHostname("www.example.com"))
+		assert.NoError(t, certs[0].VerifyHostname("example.com"))
+	}
+	assert.True(t, c.isPlaceholderCert("tls/tls-example.pem", data))
+
+	// reused for the same hosts
+	assert.NoError(t, c.projectPlaceholderCert("tls/tls-example.pem", []string{"example.com", "www.example.com"}, projections))
+	assert.Equal(t, data, projections["tls/tls-example.pem"].Data)
+
+	// regenerated when hosts change
+	assert.NoError(t, c.projectPlaceholderCert("tls/tls-example.pem", []string{"example.com"}, projections))
+	assert.NotEqual(t, data, projections["tls/tls-example.pem"].Data)
+	assert.False(t, c.isPlaceholderCert("tls/tls-example.pem", data))
+}
@@ -2,9 +2,13 @@ package controller
 
 import (
 	"fmt"
+	"io/ioutil"
+	"net"
 	"os"
 	"os/exec"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/golang/glog"
 	"github.com/mitchellh/go-ps"
@@ -99,3 +103,25 @@ func startOrReloadHaproxy() error {
 		return reloadHAProxy(pid)
 	}
 }
+
+// runHAProxyCommand sends a command to haproxy runtime api and returns its output
+// ref: https://cbonte.github.io/haproxy-dconv/1.8/management.html#9.3
+func runHAProxyCommand(cmd string) (string, error) {
+	conn, err := net.DialTimeout("unix", haproxySocket, 5*time.Second)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to connect to haproxy socket")
+	}
+	defer conn.Close()
+
+	if err = conn.SetDeadline(time.Now().Add(30 * time.Second)); err != nil {
+		return "", err
+	}
+	if _, err = conn.Write([]byte(cmd + "\n")); err != nil {
+		return "", errors.Wrap(err, "failed to send command to haproxy")
+	}
+	out, err := ioutil.ReadAll(conn)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read haproxy response")
+	}
+	return strings.TrimSpace(string(out)), nil
+}
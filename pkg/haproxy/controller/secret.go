@@ -5,9 +5,11 @@ import (
 
 	ioutilz "github.com/appscode/go/ioutil"
 	"github.com/appscode/kutil/tools/queue"
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
 	"github.com/pkg/errors"
 	core "k8s.io/api/core/v1"
 	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -52,6 +54,11 @@ func (c *Controller) isSecretUsedForTLSTermination(s *core.Secret) bool {
 			return true
 		}
 	}
+	if ref := r.Spec.DefaultCertificate; ref != nil {
+		if s.Name == ref.Name && (strings.EqualFold(ref.Kind, "Secret") || ref.Kind == "") {
+			return true
+		}
+	}
 
 	return false
 }
@@ -71,6 +78,11 @@ func (c *Controller) isSecretUsedForTLSAuth(s *core.Secret) bool {
 			}
 		}
 	}
+	for _, tls := range r.Spec.TLS {
+		if tls.Auth != nil && tls.Auth.SecretName == s.Name {
+			return true
+		}
+	}
 
 	return false
 }
@@ -98,19 +110,32 @@ func (c *Controller) getSecret(name string) (*core.Secret, error) {
 	return obj.(*core.Secret), nil
 }
 
-func (c *Controller) projectTLSSecret(r *core.Secret, projections map[string]ioutilz.FileProjection) error {
+func (c *Controller) projectTLSSecret(r *core.Secret, certFile string, projections map[string]ioutilz.FileProjection) error {
 	pemKey, found := r.Data[core.TLSPrivateKeyKey]
 	if !found {
-		return errors.Errorf("secret %s/%s is missing tls.key", c.options.IngressRef.Namespace, r.Name)
+		return errors.Errorf("secret %s/%s is missing tls.key", r.Namespace, r.Name)
 	}
 
 	pemCrt, found := r.Data[core.TLSCertKey]
 	if !found {
-		return errors.Errorf("secret %s/%s is missing tls.crt", c.options.IngressRef.Namespace, r.Name)
+		return errors.Errorf("secret %s/%s is missing tls.crt", r.Namespace, r.Name)
 	}
 
-	projections["tls/"+r.Name+".pem"] = ioutilz.FileProjection{Mode: 0755, Data: certificateToPEMData(pemCrt, pemKey)}
-	return nil
+	ecdsaCrt, ecdsaKey := r.Data[api.TLSECDSACertKey], r.Data[api.TLSECDSAPrivateKeyKey]
+	if (ecdsaCrt == nil) != (ecdsaKey == nil) {
+		return errors.Errorf("secret %s/%s must contain both %s and %s", r.Namespace, r.Name, api.TLSECDSACertKey, api.TLSECDSAPrivateKeyKey)
+	}
+	return projectCertBundle(certFile, pemCrt, pemKey, ecdsaCrt, ecdsaKey, projections)
+}
+
+// getDefaultCertificate reads the default certificate of operator. It is not cached by secretInformer,
+// which only watches the namespace of Ingress.
+func (c *Controller) getDefaultCertificate() (*core.Secret, error) {
+	ns, name, err := cache.SplitMetaNamespaceKey(c.options.DefaultCertificate)
+	if err != nil {
+		return nil, err
+	}
+	return c.k8sClient.CoreV1().Secrets(ns).Get(name, metav1.GetOptions{})
 }
 
 func (c *Controller) projectAuthSecret(r *core.Secret, projections map[string]ioutilz.FileProjection) error {
@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/appscode/voyager/apis/voyager"
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	"github.com/appscode/voyager/client/clientset/versioned/typed/voyager/v1beta1/util"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// updateConfigAppliedCondition reports in status.pods the haproxy.cfg this pod is running with, and sets ConfigApplied
+// condition aggregated over all HAProxy pods of the Ingress. mountErr is the error of mounting config and certificates.
+// HAProxy pods may write only the status subresource, so nothing is reported on Kubernetes older than 1.11.
+func (c *Controller) updateConfigAppliedCondition(ing *api.Ingress, mountErr error) error {
+	if !voyager.EnableStatusSubresource {
+		return nil
+	}
+	self := api.IngressPodStatus{
+		Name:       c.options.PodName,
+		ConfigHash: c.configHash,
+	}
+	if mountErr != nil {
+		self.Message = mountErr.Error()
+	}
+	if isConfigApplied(ing.Status, self) {
+		return nil
+	}
+
+	pods, err := c.k8sClient.CoreV1().Pods(ing.Namespace).List(metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(ing.OffshootSelector()).String(),
+	})
+	if err != nil {
+		return err
+	}
+	live := sets.NewString(self.Name)
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp == nil && pod.Status.Phase != core.PodSucceeded && pod.Status.Phase != core.PodFailed {
+			live.Insert(pod.Name)
+		}
+	}
+
+	_, err = util.UpdateIngressStatus(c.VoyagerClient.VoyagerV1beta1(), ing, func(in *api.IngressStatus) *api.IngressStatus {
+		in.Pods = setPodStatus(in.Pods, self, live)
+		in.Conditions = api.SetIngressCondition(in.Conditions, configAppliedCondition(in.ConfigHash, in.Pods), metav1.Now())
+		return in
+	}, true)
+	return err
+}
+
+// isConfigApplied returns true if status already has self and ConfigApplied condition is true,
+// so that pods are listed only when status.pods or the condition needs to change.
+func isConfigApplied(status api.IngressStatus, self api.IngressPodStatus) bool {
+	if cond := api.GetIngressCondition(status.Conditions, api.IngressConfigApplied); cond == nil || cond.Status != core.ConditionTrue {
+		return false
+	}
+	found := false
+	for _, pod := range status.Pods {
+		if pod.Message != "" || (status.ConfigHash != "" && pod.ConfigHash != status.ConfigHash) {
+			return false
+		}
+		if pod.Name == self.Name {
+			found = pod == self
+		}
+	}
+	return found
+}
+
+// setPodStatus replaces the entry of self in pods, and drops the entries of pods that are not live anymore.
+func setPodStatus(pods []api.IngressPodStatus, self api.IngressPodStatus, live sets.String) []api.IngressPodStatus {
+	out := make([]api.IngressPodStatus, 0, live.Len())
+	for _, pod := range pods {
+		if pod.Name != self.Name && live.Has(pod.Name) {
+			out = append(out, pod)
+		}
+	}
+	out = append(out, self)
+	for _, name := range live.List() {
+		found := false
+		for _, pod := range out {
+			found = found || pod.Name == name
+		}
+		if !found {
+			// pod has not reported yet
+			out = append(out, api.IngressPodStatus{Name: name})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// configAppliedCondition returns ConfigApplied condition, given the hash of haproxy.cfg rendered by
+// the operator and the state of HAProxy pods.
+func configAppliedCondition(rendered string, pods []api.IngressPodStatus) api.IngressCondition {
+	var failed, pending []string
+	applied := rendered
+	for _, pod := range pods {
+		switch {
+		case pod.Message != "":
+			failed = append(failed, pod.Name+": "+pod.Message)
+		case pod.ConfigHash == "" || (rendered != "" && rendered != pod.ConfigHash):
+			pending = append(pending, pod.Name)
+		default:
+			applied = pod.ConfigHash
+		}
+	}
+	switch {
+	case len(failed) > 0:
+		return api.IngressCondition{
+			Type:    api.IngressConfigApplied,
+			Status:  core.ConditionFalse,
+			Reason:  "MountFailed",
+			Message: "Failed in pods " + strings.Join(failed, "; "),
+		}
+	case len(pending) > 0:
+		return api.IngressCondition{
+			Type:    api.IngressConfigApplied,
+			Status:  core.ConditionUnknown,
+			Reason:  "ConfigPending",
+			Message: "Waiting for haproxy.cfg " + rendered + " to be mounted in pods " + strings.Join(pending, ", "),
+		}
+	}
+	return api.IngressCondition{
+		Type:    api.IngressConfigApplied,
+		Status:  core.ConditionTrue,
+		Reason:  "Applied",
+		Message: "HAProxy pods are running with haproxy.cfg " + applied,
+	}
+}
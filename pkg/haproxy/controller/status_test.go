@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/appscode/voyager/apis/voyager"
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	vfake "github.com/appscode/voyager/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	clientgotesting "k8s.io/client-go/testing"
+)
+
+func TestUpdateConfigAppliedCondition(t *testing.T) {
+	voyager.EnableStatusSubresource = true
+	defer func() { voyager.EnableStatusSubresource = false }()
+
+	ing := &api.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ingress", Namespace: "default"},
+		Status: api.IngressStatus{
+			ConfigHash: "abc",
+			Pods: []api.IngressPodStatus{
+				{Name: "pod-a", ConfigHash: "abc"},
+				{Name: "pod-gone", ConfigHash: "old"},
+			},
+		},
+	}
+	// status was updated by operator since ing was cached
+	latest := ing.DeepCopy()
+	latest.Status.Conditions = api.SetIngressCondition(nil, api.IngressCondition{
+		Type:   api.IngressCertificatesReady,
+		Status: core.ConditionTrue,
+	}, metav1.Now())
+
+	pod := func(name string) runtime.Object {
+		return &core.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: ing.OffshootSelector()}}
+	}
+	vc := vfake.NewSimpleClientset(latest)
+	conflicted := false
+	vc.PrependReactor("update", "ingresses", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		if conflicted {
+			return false, nil, nil
+		}
+		conflicted = true
+		return true, nil, kerr.NewConflict(schema.GroupResource{Group: api.SchemeGroupVersion.Group, Resource: "ingresses"}, ing.Name, errors.New("object has been modified"))
+	})
+	c := &Controller{
+		k8sClient:     fake.NewSimpleClientset(pod("pod-a"), pod("pod-b")),
+		VoyagerClient: vc,
+		options:       Options{PodName: "pod-b"},
+		configHash:    "abc",
+	}
+
+	if !assert.NoError(t, c.updateConfigAppliedCondition(ing, nil)) {
+		return
+	}
+	assert.True(t, conflicted)
+	out, err := vc.VoyagerV1beta1().Ingresses("default").Get(ing.Name, metav1.GetOptions{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []api.IngressPodStatus{
+		{Name: "pod-a", ConfigHash: "abc"},
+		{Name: "pod-b", ConfigHash: "abc"},
+	}, out.Status.Pods)
+	assert.Equal(t, core.ConditionTrue, api.GetIngressCondition(out.Status.Conditions, api.IngressCertificatesReady).Status)
+	assert.Equal(t, core.ConditionTrue, api.GetIngressCondition(out.Status.Conditions, api.IngressConfigApplied).Status)
+	assert.True(t, isConfigApplied(out.Status, api.IngressPodStatus{Name: "pod-b", ConfigHash: "abc"}))
+}
+
+func TestConfigAppliedCondition(t *testing.T) {
+	cond := configAppliedCondition("abc", []api.IngressPodStatus{
+		{Name: "pod-a", ConfigHash: "abc"},
+		{Name: "pod-b", ConfigHash: "old"},
+		{Name: "pod-c"},
+	})
+	assert.Equal(t, core.ConditionUnknown, cond.Status)
+	assert.Equal(t, "Waiting for haproxy.cfg abc to be mounted in pods pod-b, pod-c", cond.Message)
+
+	cond = configAppliedCondition("abc", []api.IngressPodStatus{
+		{Name: "pod-a", ConfigHash: "abc"},
+		{Name: "pod-b", ConfigHash: "old", Message: "secret not found"},
+	})
+	assert.Equal(t, core.ConditionFalse, cond.Status)
+	assert.Equal(t, "Failed in pods pod-b: secret not found", cond.Message)
+}
@@ -2,6 +2,8 @@ package template
 
 import (
 	"bytes"
+	"crypto/md5"
+	"encoding/hex"
 	"strings"
 
 	"github.com/appscode/go/log"
@@ -29,3 +31,9 @@ func RenderConfig(data hpi.TemplateData) (string, error) {
 	}
 	return strings.Join(result, "\n"), nil
 }
+
+// ConfigHash returns the md5 hash of a rendered haproxy.cfg, as reported in Ingress status.
+func ConfigHash(cfg string) string {
+	sum := md5.Sum([]byte(cfg))
+	return hex.EncodeToString(sum[:])
+}
@@ -3,6 +3,7 @@ package template
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"testing"
 	"text/template"
 
@@ -846,3 +847,71 @@ func TestOauth(t *testing.T) {
 		}
 	}
 }
+
+func TestACMEChallenge(t *testing.T) {
+	testParsedConfig := hpi.TemplateData{
+		SharedInfo: &hpi.SharedInfo{},
+		HTTPService: []*hpi.HTTPService{
+			{
+				SharedInfo:    &hpi.SharedInfo{},
+				FrontendName:  "http-0_0_0_0-80",
+				Port:          80,
+				ACMEChallenge: true,
+				Hosts: []*hpi.HTTPHost{
+					{
+						Host: "",
+						Paths: []*hpi.HTTPPath{
+							{
+								Path: "/.well-known/acme-challenge/",
+								Backend: &hpi.Backend{
+									Name:      "voyager-operator.kube-system:56791",
+									Endpoints: []*hpi.Endpoint{{Name: "first", IP: "10.244.2.1", Port: "56791"}},
+								},
+							},
+						},
+					},
+					{
+						Host: "ex.appscode.dev",
+						Paths: []*hpi.HTTPPath{
+							{Path: "/", SSLRedirect: true},
+						},
+					},
+					{
+						Host: "web.appscode.dev",
+						Paths: []*hpi.HTTPPath{
+							{
+								Path:    "/",
+								Backend: &hpi.Backend{Name: "web", Endpoints: []*hpi.Endpoint{{Name: "web", IP: "10.244.2.2", Port: "80"}}},
+							},
+							{
+								Path: "/.well-known/acme-challenge/",
+								Backend: &hpi.Backend{
+									Name:          "voyager-operator.kube-system:56791",
+									NameGenerated: true,
+									Endpoints:     []*hpi.Endpoint{{Name: "first", IP: "10.244.2.1", Port: "56791"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	err := LoadTemplates(runtime.GOPath()+"/src/github.com/appscode/voyager/hack/docker/voyager/templates/*.cfg", "")
+	if assert.Nil(t, err) {
+		config, err := RenderConfig(testParsedConfig)
+		if assert.Nil(t, err) {
+			assert.Contains(t, config, "acl __acme_challenge__ path_beg /.well-known/acme-challenge/")
+			assert.Contains(t, config, "!__acme_challenge__")
+			assert.Contains(t, config, "use_backend voyager-operator.kube-system:56791")
+
+			// challenge path of a host is routed before its other paths
+			acme := strings.Index(config, "use_backend voyager-operator.kube-system:56791-")
+			web := strings.Index(config, "use_backend web ")
+			assert.True(t, acme >= 0 && acme < web)
+		}
+		if testing.Verbose() {
+			fmt.Println(err, "\n", config)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package ingress
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/appscode/kutil"
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	"github.com/appscode/voyager/client/clientset/versioned/typed/voyager/v1beta1/util"
+	"github.com/appscode/voyager/pkg/certificate/providers"
+	"github.com/appscode/voyager/pkg/eventer"
+	hpi "github.com/appscode/voyager/pkg/haproxy/api"
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// desiredCertificates returns the Certificates to be issued for spec.tls of an Ingress with acme-issuer
+// annotation, keyed by name. Certificates are named after the referred Secret or Certificate.
+// Wildcard hosts are skipped, since they can't be verified via http-01 challenge.
+// Since a Secret and a Certificate of the same name would need the same Certificate, the names of
+// such conflicting entries are returned and only the first entry is used.
+func desiredCertificates(ing *api.Ingress) (map[string]api.CertificateSpec, []string) {
+	result := map[string]api.CertificateSpec{}
+	var conflicts []string
+	if ing.ACMEIssuerSecret() == "" {
+		return result, conflicts
+	}
+
+	apiVersion := api.SchemeGroupVersion.String()
+	if ing.APISchema() == api.APISchemaIngress {
+		apiVersion = "extensions/v1beta1"
+	}
+	for _, tls := range ing.Spec.TLS {
+		if tls.Ref == nil || tls.Ref.Name == "" {
+			continue
+		}
+		var domains []string
+		for _, host := range tls.Hosts {
+			if host != "" && !strings.HasPrefix(host, "*") {
+				domains = append(domains, host)
+			}
+		}
+		if len(domains) == 0 {
+			continue
+		}
+
+		spec := api.CertificateSpec{
+			Domains: domains,
+			ChallengeProvider: api.ChallengeProvider{
+				HTTP: &api.HTTPChallengeProvider{
+					Ingress: api.LocalTypedReference{
+						APIVersion: apiVersion,
+						Kind:       api.ResourceKindIngress,
+						Name:       ing.Name,
+					},
+				},
+			},
+			ACMEUserSecretName: ing.ACMEIssuerSecret(),
+		}
+		if !strings.EqualFold(tls.Ref.Kind, api.ResourceKindCertificate) {
+			spec.Storage.Secret = &core.LocalObjectReference{Name: tls.Ref.Name}
+		}
+		if cur, found := result[tls.Ref.Name]; found {
+			if (cur.Storage.Secret == nil) != (spec.Storage.Secret == nil) {
+				conflicts = append(conflicts, tls.Ref.Name)
+				continue
+			}
+			// multiple tls entries refer to the same secret
+			spec.Domains = append(cur.Domains, spec.Domains...)
+		}
+		result[tls.Ref.Name] = spec
+	}
+	return result, conflicts
+}
+
+func (c *controller) isOwnedCertificate(crd *api.Certificate) bool {
+	for _, ref := range crd.OwnerReferences {
+		if ref.Kind == api.ResourceKindIngress && ref.UID == c.Ingress.UID {
+			return true
+		}
+	}
+	return false
+}
+
+// ensureCertificates creates Certificates for the tls hosts of this Ingress and deletes the
+// Certificates created earlier for hosts that are no longer used. Existing Certificates are read
+// from the informer cache, so unchanged Certificates cost no api call.
+func (c *controller) ensureCertificates() error {
+	desired, conflicts := desiredCertificates(c.Ingress)
+	if c.Ingress.SSLPassthrough() {
+		desired, conflicts = map[string]api.CertificateSpec{}, nil
+	}
+	for _, name := range conflicts {
+		c.recorder.Eventf(
+			c.Ingress.ObjectReference(),
+			core.EventTypeWarning,
+			eventer.EventReasonIngressCertificateReconcileFailed,
+			"TLS entries refer to both Secret and Certificate %s, only the first one is issued",
+			name,
+		)
+	}
+
+	for name, spec := range desired {
+		crd, err := c.CertificateLister.Certificates(c.Ingress.Namespace).Get(name)
+		if err == nil && !c.isOwnedCertificate(crd) {
+			c.recorder.Eventf(
+				c.Ingress.ObjectReference(),
+				core.EventTypeWarning,
+				eventer.EventReasonIngressCertificateReconcileFailed,
+				"Certificate %s already exists and is not owned by this Ingress",
+				name,
+			)
+			continue
+		} else if err != nil && !kerr.IsNotFound(err) {
+			return errors.WithStack(err)
+		} else if err == nil && isCertificateUpToDate(crd, spec, c.Ingress) {
+			continue
+		}
+
+		meta := metav1.ObjectMeta{
+			Name:      name,
+			Namespace: c.Ingress.Namespace,
+		}
+		_, vt, err := util.CreateOrPatchCertificate(c.VoyagerClient.VoyagerV1beta1(), meta, func(obj *api.Certificate) *api.Certificate {
+			obj.ObjectMeta = c.ensureOwnerReference(obj.ObjectMeta)
+			if obj.Annotations == nil {
+				obj.Annotations = make(map[string]string)
+			}
+			obj.Annotations[api.OriginAPISchema] = c.Ingress.APISchema()
+			obj.Annotations[api.OriginName] = c.Ingress.GetName()
+			obj.Spec.Domains = spec.Domains
+			obj.Spec.ChallengeProvider = spec.ChallengeProvider
+			obj.Spec.ACMEUserSecretName = spec.ACMEUserSecretName
+			obj.Spec.Storage = spec.Storage
+			return obj
+		})
+		if err != nil {
+			c.recorder.Eventf(
+				c.Ingress.ObjectReference(),
+				core.EventTypeWarning,
+				eventer.EventReasonIngressCertificateReconcileFailed,
+				"Failed to reconcile Certificate %s, Reason: %v",
+				name,
+				err,
+			)
+			return errors.WithStack(err)
+		}
+		if vt != kutil.VerbUnchanged {
+			c.recorder.Eventf(
+				c.Ingress.ObjectReference(),
+				core.EventTypeNormal,
+				eventer.EventReasonIngressCertificateReconcileSuccessful,
+				"Successfully %s Certificate %s",
+				vt,
+				name,
+			)
+		}
+	}
+
+	return c.deleteCertificates(func(name string) bool {
+		_, found := desired[name]
+		return !found
+	})
+}
+
+// isCertificateUpToDate returns true if an owned Certificate already matches the desired spec,
+// so it is not patched.
+func isCertificateUpToDate(crd *api.Certificate, spec api.CertificateSpec, ing *api.Ingress) bool {
+	return crd.Annotations[api.OriginAPISchema] == ing.APISchema() &&
+		crd.Annotations[api.OriginName] == ing.GetName() &&
+		reflect.DeepEqual(crd.Spec.Domains, spec.Domains) &&
+		reflect.DeepEqual(crd.Spec.ChallengeProvider, spec.ChallengeProvider) &&
+		crd.Spec.ACMEUserSecretName == spec.ACMEUserSecretName &&
+		reflect.DeepEqual(crd.Spec.Storage, spec.Storage)
+}
+
+// ensureCertificatesDeleted deletes all Certificates created for this Ingress.
+func (c *controller) ensureCertificatesDeleted() error {
+	return c.deleteCertificates(func(string) bool { return true })
+}
+
+func (c *controller) deleteCertificates(shouldDelete func(name string) bool) error {
+	if c.Ingress.UID == "" {
+		return nil
+	}
+	crds, err := c.CertificateLister.Certificates(c.Ingress.Namespace).List(labels.Everything())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, crd := range crds {
+		if !c.isOwnedCertificate(crd) || !shouldDelete(crd.Name) {
+			continue
+		}
+		c.logger.Infof("Deleting Certificate %s/%s", crd.Namespace, crd.Name)
+		err := c.VoyagerClient.VoyagerV1beta1().Certificates(crd.Namespace).Delete(crd.Name, &metav1.DeleteOptions{})
+		if err != nil && !kerr.IsNotFound(err) {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// acmeChallengeBackend returns the backend for operator's http-01 challenge responder. HAProxy routes
+// challenge requests on port 80 to it, so certificates are issued without adding a rule to the Ingress.
+// It returns nil if the responder is unavailable.
+func (c *controller) acmeChallengeBackend(userLists map[string]hpi.UserList) *hpi.Backend {
+	if c.cfg.OperatorService == "" || c.cfg.OperatorNamespace == "" {
+		return nil
+	}
+	svc, err := c.ServiceLister.Services(c.cfg.OperatorNamespace).Get(c.cfg.OperatorService)
+	if err != nil {
+		c.logger.Warningf("failed to get operator service %s/%s. Reason: %v", c.cfg.OperatorNamespace, c.cfg.OperatorService, err)
+		return nil
+	}
+	port := intstr.FromInt(providers.ACMEResponderPort)
+	p, ok := getSpecifiedPort(svc.Spec.Ports, port)
+	if !ok {
+		c.logger.Warningf("operator service %s/%s does not expose port %d", svc.Namespace, svc.Name, providers.ACMEResponderPort)
+		return nil
+	}
+	bk, err := c.getEndpoints(svc, p, nil, userLists)
+	if err != nil || len(bk.Endpoints) == 0 {
+		c.logger.Warningf("no endpoint found for operator service %s/%s. Reason: %v", svc.Namespace, svc.Name, err)
+		return nil
+	}
+	return &hpi.Backend{
+		Name: getBackendName(c.Ingress, api.IngressBackend{
+			ServiceName: svc.Name + "." + svc.Namespace,
+			ServicePort: port,
+		}),
+		NameGenerated: true,
+		Endpoints:     bk.Endpoints,
+	}
+}
+
+// addACMEChallengePaths routes challenge requests of each host, and of the catch-all host, to bk unless
+// the user already routes them for that host. Host specific rules take precedence over the catch-all
+// host, so it is not enough to route them for the catch-all host. It returns true, if any path is added.
+func addACMEChallengePaths(hosts map[string][]*hpi.HTTPPath, bk *hpi.Backend) bool {
+	if _, found := hosts[""]; !found {
+		hosts[""] = nil
+	}
+	added := false
+	for host, paths := range hosts {
+		if hasACMEChallengePath(paths) {
+			continue
+		}
+		backend := *bk // backend names are made unique per host while rendering
+		hosts[host] = append(paths, &hpi.HTTPPath{
+			Path:    providers.URLPrefix,
+			Backend: &backend,
+		})
+		added = true
+	}
+	return added
+}
+
+// hasACMEChallengePath returns true if the user has already routed challenge requests for a host.
+func hasACMEChallengePath(paths []*hpi.HTTPPath) bool {
+	for _, path := range paths {
+		if strings.HasPrefix(path.Path, strings.TrimSuffix(providers.URLPrefix, "/")) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package ingress
+
+import (
+	"context"
+	"testing"
+
+	"github.com/appscode/go/log"
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	vfake "github.com/appscode/voyager/client/clientset/versioned/fake"
+	api_listers "github.com/appscode/voyager/client/listers/voyager/v1beta1"
+	hpi "github.com/appscode/voyager/pkg/haproxy/api"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestDesiredCertificates(t *testing.T) {
+	ing := &api.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: api.IngressSpec{
+			TLS: []api.IngressTLS{
+				{Hosts: []string{"one.example.com", "*.example.com"}, Ref: &api.LocalTypedReference{Kind: "Secret", Name: "one"}},
+				{Hosts: []string{"two.example.com"}, Ref: &api.LocalTypedReference{Kind: "Certificate", Name: "two"}},
+				{Hosts: []string{"*.example.org"}, Ref: &api.LocalTypedReference{Kind: "Secret", Name: "wildcard"}},
+				{Hosts: []string{"three.example.com"}, Ref: &api.LocalTypedReference{Name: "one"}},
+				{Hosts: []string{"four.example.com"}, Ref: &api.LocalTypedReference{Kind: "Certificate", Name: "one"}},
+			},
+		},
+	}
+	certs, conflicts := desiredCertificates(ing)
+	assert.Empty(t, certs)
+	assert.Empty(t, conflicts)
+
+	ing.Annotations = map[string]string{api.ACMEIssuerSecret: "acme-account"}
+	certs, conflicts = desiredCertificates(ing)
+	assert.Equal(t, []string{"one"}, conflicts)
+	if assert.Len(t, certs, 2) {
+		assert.Equal(t, []string{"one.example.com", "three.example.com"}, certs["one"].Domains)
+		assert.Equal(t, "one", certs["one"].Storage.Secret.Name)
+		assert.Equal(t, "acme-account", certs["one"].ACMEUserSecretName)
+		assert.Equal(t, api.LocalTypedReference{
+			APIVersion: api.SchemeGroupVersion.String(),
+			Kind:       api.ResourceKindIngress,
+			Name:       "test",
+		}, certs["one"].ChallengeProvider.HTTP.Ingress)
+
+		assert.Equal(t, []string{"two.example.com"}, certs["two"].Domains)
+		assert.Nil(t, certs["two"].Storage.Secret)
+	}
+
+	ing.Annotations[api.APISchema] = api.APISchemaIngress
+	certs, _ = desiredCertificates(ing)
+	assert.Equal(t, "extensions/v1beta1", certs["two"].ChallengeProvider.HTTP.Ingress.APIVersion)
+}
+
+func TestEnsureCertificates(t *testing.T) {
+	ing := &api.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "default",
+			UID:         "uid",
+			Annotations: map[string]string{api.ACMEIssuerSecret: "acme-account"},
+		},
+		Spec: api.IngressSpec{
+			TLS: []api.IngressTLS{
+				{Hosts: []string{"one.example.com"}, Ref: &api.LocalTypedReference{Kind: "Secret", Name: "one"}},
+			},
+		},
+	}
+	c := &controller{Ingress: ing, logger: log.New(context.Background()), recorder: record.NewFakeRecorder(10)}
+	owned := func(name string) *api.Certificate {
+		return &api.Certificate{ObjectMeta: c.ensureOwnerReference(metav1.ObjectMeta{Name: name, Namespace: "default"})}
+	}
+	desired, _ := desiredCertificates(ing)
+	one := owned("one")
+	one.Annotations = map[string]string{api.OriginAPISchema: ing.APISchema(), api.OriginName: ing.Name}
+	one.Spec = desired["one"]
+	stale := owned("stale")
+
+	c.VoyagerClient = vfake.NewSimpleClientset(one, stale)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NoError(t, indexer.Add(one))
+	assert.NoError(t, indexer.Add(stale))
+	c.CertificateLister = api_listers.NewCertificateLister(indexer)
+
+	// up to date certificate is read from cache, only the stale one is deleted
+	assert.NoError(t, c.ensureCertificates())
+	actions := c.VoyagerClient.(*vfake.Clientset).Actions()
+	if assert.Len(t, actions, 1) {
+		assert.True(t, actions[0].Matches("delete", "certificates"))
+	}
+}
+
+func TestAddACMEChallengePaths(t *testing.T) {
+	bk := &hpi.Backend{Name: "voyager-operator.kube-system:56791", NameGenerated: true}
+	hosts := map[string][]*hpi.HTTPPath{
+		"one.example.com": {{Path: "/", SSLRedirect: true}},
+		"two.example.com": {{Path: "/.well-known/acme-challenge/", Backend: &hpi.Backend{Name: "user"}}},
+	}
+	assert.True(t, addACMEChallengePaths(hosts, bk))
+
+	// routed for each host, unless routed by user
+	for _, host := range []string{"", "one.example.com"} {
+		paths := hosts[host]
+		if assert.NotEmpty(t, paths, host) {
+			assert.Equal(t, "/.well-known/acme-challenge/", paths[len(paths)-1].Path)
+			assert.Equal(t, bk.Name, paths[len(paths)-1].Backend.Name)
+		}
+	}
+	assert.Len(t, hosts["two.example.com"], 1)
+	assert.Equal(t, "user", hosts["two.example.com"][0].Backend.Name)
+	assert.True(t, hosts[""][0].Backend != hosts["one.example.com"][1].Backend)
+}
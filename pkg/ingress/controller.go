@@ -14,6 +14,8 @@ import (
 	v1u "github.com/appscode/kutil/core/v1"
 	api "github.com/appscode/voyager/apis/voyager/v1beta1"
 	cs "github.com/appscode/voyager/client/clientset/versioned"
+	api_listers "github.com/appscode/voyager/client/listers/voyager/v1beta1"
+	"github.com/appscode/voyager/pkg/certificate"
 	"github.com/appscode/voyager/pkg/config"
 	_ "github.com/appscode/voyager/third_party/forked/cloudprovider/providers"
 	pcm "github.com/coreos/prometheus-operator/pkg/client/monitoring/v1"
@@ -35,13 +37,14 @@ type Controller interface {
 }
 
 type controller struct {
-	KubeClient      kubernetes.Interface
-	WorkloadClient  wcs.Interface
-	CRDClient       kext_cs.ApiextensionsV1beta1Interface
-	VoyagerClient   cs.Interface
-	PromClient      pcm.MonitoringV1Interface
-	ServiceLister   core_listers.ServiceLister
-	EndpointsLister core_listers.EndpointsLister
+	KubeClient        kubernetes.Interface
+	WorkloadClient    wcs.Interface
+	CRDClient         kext_cs.ApiextensionsV1beta1Interface
+	VoyagerClient     cs.Interface
+	PromClient        pcm.MonitoringV1Interface
+	ServiceLister     core_listers.ServiceLister
+	EndpointsLister   core_listers.EndpointsLister
+	CertificateLister api_listers.CertificateLister
 
 	recorder record.EventRecorder
 
@@ -52,6 +55,8 @@ type controller struct {
 
 	// contains raw configMap data parsed from the cfg file.
 	HAProxyConfig string
+	// result of resolving the backend of each rule and path in HAProxyConfig
+	backends []backendStatus
 
 	logger *log.Logger
 	sync.Mutex
@@ -66,17 +71,18 @@ func NewController(
 	promClient pcm.MonitoringV1Interface,
 	serviceLister core_listers.ServiceLister,
 	endpointsLister core_listers.EndpointsLister,
+	certificateLister api_listers.CertificateLister,
 	cfg config.Config,
 	ingress *api.Ingress) Controller {
 	switch ingress.LBType() {
 	case api.LBTypeHostPort:
-		return NewHostPortController(ctx, kubeClient, workloadClient, crdClient, extClient, promClient, serviceLister, endpointsLister, cfg, ingress)
+		return NewHostPortController(ctx, kubeClient, workloadClient, crdClient, extClient, promClient, serviceLister, endpointsLister, certificateLister, cfg, ingress)
 	case api.LBTypeNodePort:
-		return NewNodePortController(ctx, kubeClient, workloadClient, crdClient, extClient, promClient, serviceLister, endpointsLister, cfg, ingress)
+		return NewNodePortController(ctx, kubeClient, workloadClient, crdClient, extClient, promClient, serviceLister, endpointsLister, certificateLister, cfg, ingress)
 	case api.LBTypeLoadBalancer:
-		return NewLoadBalancerController(ctx, kubeClient, workloadClient, crdClient, extClient, promClient, serviceLister, endpointsLister, cfg, ingress)
+		return NewLoadBalancerController(ctx, kubeClient, workloadClient, crdClient, extClient, promClient, serviceLister, endpointsLister, certificateLister, cfg, ingress)
 	case api.LBTypeInternal:
-		return NewInternalController(ctx, kubeClient, workloadClient, crdClient, extClient, promClient, serviceLister, endpointsLister, cfg, ingress)
+		return NewInternalController(ctx, kubeClient, workloadClient, crdClient, extClient, promClient, serviceLister, endpointsLister, certificateLister, cfg, ingress)
 	}
 	return nil
 }
@@ -102,6 +108,15 @@ func (c *controller) ensureOwnerReference(in metav1.ObjectMeta) metav1.ObjectMet
 }
 
 func (c *controller) ensureEnvVars(vars []core.EnvVar) []core.EnvVar {
+	// haproxy-controller reports its pod in status.pods of the Ingress
+	vars = v1u.UpsertEnvVars(vars, core.EnvVar{
+		Name: "POD_NAME",
+		ValueFrom: &core.EnvVarSource{
+			FieldRef: &core.ObjectFieldSelector{
+				FieldPath: "metadata.name",
+			},
+		},
+	})
 	if addr := os.Getenv(vault.EnvVaultAddress); addr != "" {
 		vars = v1u.UpsertEnvVars(vars, core.EnvVar{
 			Name:  vault.EnvVaultAddress,
@@ -120,6 +135,15 @@ func (c *controller) ensureEnvVars(vars []core.EnvVar) []core.EnvVar {
 				Value: string(caCert),
 			})
 		}
+		// haproxy pods log in to vault with their own service account token
+		for _, name := range []string{certificate.EnvVaultNamespace, certificate.EnvVaultKubernetesRole, certificate.EnvVaultKubernetesMountPath} {
+			if value := os.Getenv(name); value != "" {
+				vars = v1u.UpsertEnvVars(vars, core.EnvVar{
+					Name:  name,
+					Value: value,
+				})
+			}
+		}
 	}
 	return vars
 }
@@ -1,6 +1,8 @@
 package ingress
 
 import (
+	"crypto/md5"
+	"encoding/hex"
 	"fmt"
 
 	tools "github.com/appscode/kube-mon"
@@ -9,9 +11,12 @@ import (
 	"github.com/appscode/kutil/tools/analytics"
 	api "github.com/appscode/voyager/apis/voyager/v1beta1"
 	"github.com/appscode/voyager/pkg/config"
+	"github.com/pkg/errors"
 	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/cache"
 )
 
 const (
@@ -26,6 +31,10 @@ func (c *controller) ensureConfigMap() (*core.ConfigMap, kutil.VerbType, error)
 		Name:      c.Ingress.OffshootName(),
 		Namespace: c.Ingress.Namespace,
 	}
+	certHash, err := c.defaultCertificateHash()
+	if err != nil {
+		return nil, kutil.VerbUnchanged, err
+	}
 	return core_util.CreateOrPatchConfigMap(c.KubeClient, meta, func(obj *core.ConfigMap) *core.ConfigMap {
 		obj.ObjectMeta = c.ensureOwnerReference(obj.ObjectMeta)
 		if obj.Annotations == nil {
@@ -33,6 +42,11 @@ func (c *controller) ensureConfigMap() (*core.ConfigMap, kutil.VerbType, error)
 		}
 		obj.Annotations[api.OriginAPISchema] = c.Ingress.APISchema()
 		obj.Annotations[api.OriginName] = c.Ingress.GetName()
+		if certHash != "" {
+			obj.Annotations[api.DefaultCertificateHash] = certHash
+		} else {
+			delete(obj.Annotations, api.DefaultCertificateHash)
+		}
 		obj.Data = map[string]string{
 			"haproxy.cfg": c.HAProxyConfig,
 		}
@@ -40,6 +54,38 @@ func (c *controller) ensureConfigMap() (*core.ConfigMap, kutil.VerbType, error)
 	})
 }
 
+// usesOperatorDefaultCertificate returns true if HAProxy pods serve the default certificate of operator
+// for this Ingress. HAProxy pods read it directly from the namespace of operator, so it is never copied
+// into the namespace of Ingress.
+func (c *controller) usesOperatorDefaultCertificate() bool {
+	return c.Ingress.Spec.DefaultCertificate == nil &&
+		c.cfg.DefaultCertificate != "" &&
+		len(c.Ingress.Spec.TLS) > 0 &&
+		!c.Ingress.SSLPassthrough()
+}
+
+// defaultCertificateHash returns the hash of the default certificate of operator, if used by this Ingress.
+// HAProxy pods do not watch the namespace of operator, so they learn about changes via ConfigMap.
+func (c *controller) defaultCertificateHash() (string, error) {
+	if !c.usesOperatorDefaultCertificate() {
+		return "", nil
+	}
+	ns, name, err := cache.SplitMetaNamespaceKey(c.cfg.DefaultCertificate)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	secret, err := c.KubeClient.CoreV1().Secrets(ns).Get(name, metav1.GetOptions{})
+	if kerr.IsNotFound(err) {
+		return "", nil // HAProxy pods serve a placeholder meanwhile
+	} else if err != nil {
+		return "", errors.WithStack(err)
+	}
+	h := md5.New()
+	h.Write(secret.Data[core.TLSCertKey])
+	h.Write(secret.Data[core.TLSPrivateKeyKey])
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 func (c *controller) getExporterSidecar() (*core.Container, error) {
 	if !c.Ingress.Stats() {
 		return nil, nil // Don't add sidecar is stats is not exposed.
@@ -0,0 +1,51 @@
+package ingress
+
+import (
+	"testing"
+
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	"github.com/appscode/voyager/pkg/config"
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDefaultCertificateHash(t *testing.T) {
+	secret := &core.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "default-tls", Namespace: "kube-system"},
+		Data:       map[string][]byte{core.TLSCertKey: []byte("crt"), core.TLSPrivateKeyKey: []byte("key")},
+	}
+	c := &controller{
+		KubeClient: fake.NewSimpleClientset(),
+		cfg:        config.Config{DefaultCertificate: "kube-system/default-tls"},
+		Ingress: &api.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-ingress", Namespace: "default"},
+			Spec:       api.IngressSpec{TLS: []api.IngressTLS{{Hosts: []string{"example.com"}}}},
+		},
+	}
+
+	// placeholder is served until the secret is created
+	hash, err := c.defaultCertificateHash()
+	assert.NoError(t, err)
+	assert.Empty(t, hash)
+
+	_, err = c.KubeClient.CoreV1().Secrets(secret.Namespace).Create(secret)
+	assert.NoError(t, err)
+	hash, err = c.defaultCertificateHash()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, hash)
+
+	// rotated certificate changes ConfigMap, so that HAProxy pods read it again
+	secret.Data[core.TLSCertKey] = []byte("rotated")
+	_, err = c.KubeClient.CoreV1().Secrets(secret.Namespace).Update(secret)
+	assert.NoError(t, err)
+	rotated, err := c.defaultCertificateHash()
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, rotated)
+
+	c.Ingress.Spec.TLS = nil
+	hash, err = c.defaultCertificateHash()
+	assert.NoError(t, err)
+	assert.Empty(t, hash)
+}
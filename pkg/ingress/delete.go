@@ -48,5 +48,6 @@ func (c *controller) ensureRBACDeleted() error {
 	if err := c.ensureServiceAccountDeleted(); err != nil && !kerr.IsNotFound(err) {
 		return errors.WithStack(err)
 	}
-	return nil
+
+	return c.ensureDefaultCertificateRBACDeleted(metav1.NamespaceNone)
 }
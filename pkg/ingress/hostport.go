@@ -18,6 +18,7 @@ import (
 	"github.com/appscode/kutil/tools/analytics"
 	api "github.com/appscode/voyager/apis/voyager/v1beta1"
 	cs "github.com/appscode/voyager/client/clientset/versioned"
+	api_listers "github.com/appscode/voyager/client/listers/voyager/v1beta1"
 	"github.com/appscode/voyager/pkg/config"
 	"github.com/appscode/voyager/pkg/eventer"
 	"github.com/appscode/voyager/third_party/forked/cloudprovider"
@@ -50,21 +51,23 @@ func NewHostPortController(
 	promClient pcm.MonitoringV1Interface,
 	serviceLister core_listers.ServiceLister,
 	endpointsLister core_listers.EndpointsLister,
+	certificateLister api_listers.CertificateLister,
 	cfg config.Config,
 	ingress *api.Ingress) Controller {
 	c := &hostPortController{
 		controller: &controller{
-			logger:          log.New(ctx),
-			KubeClient:      kubeClient,
-			WorkloadClient:  workloadClient,
-			CRDClient:       crdClient,
-			VoyagerClient:   extClient,
-			PromClient:      promClient,
-			ServiceLister:   serviceLister,
-			EndpointsLister: endpointsLister,
-			cfg:             cfg,
-			Ingress:         ingress,
-			recorder:        eventer.NewEventRecorder(kubeClient, "voyager operator"),
+			logger:            log.New(ctx),
+			KubeClient:        kubeClient,
+			WorkloadClient:    workloadClient,
+			CRDClient:         crdClient,
+			VoyagerClient:     extClient,
+			PromClient:        promClient,
+			ServiceLister:     serviceLister,
+			EndpointsLister:   endpointsLister,
+			CertificateLister: certificateLister,
+			cfg:               cfg,
+			Ingress:           ingress,
+			recorder:          eventer.NewEventRecorder(kubeClient, "voyager operator"),
 		},
 	}
 	c.logger.Infoln("Initializing cloud manager for provider", cfg.CloudProvider)
@@ -101,7 +104,9 @@ func NewHostPortController(
 }
 
 func (c *hostPortController) Reconcile() error {
-	if err := c.generateConfig(); err != nil {
+	err := c.generateConfig()
+	c.updateConfigStatus(err)
+	if err != nil {
 		c.recorder.Eventf(
 			c.Ingress.ObjectReference(),
 			core.EventTypeWarning,
@@ -133,6 +138,10 @@ func (c *hostPortController) Reconcile() error {
 		)
 	}
 
+	if err := c.ensureCertificates(); err != nil {
+		return errors.WithStack(err)
+	}
+
 	// If RBAC is enabled we need to ensure service account
 	if c.cfg.EnableRBAC {
 		c.reconcileRBAC()
@@ -284,6 +293,9 @@ func (c *hostPortController) Delete() {
 	if err := c.deleteConfigMap(); err != nil {
 		c.logger.Errorln(err)
 	}
+	if err := c.ensureCertificatesDeleted(); err != nil {
+		c.logger.Errorln(err)
+	}
 	if c.cfg.EnableRBAC {
 		if err := c.ensureRBACDeleted(); err != nil {
 			c.logger.Errorln(err)
@@ -510,6 +522,10 @@ func (c *hostPortController) ensurePods() (kutil.VerbType, error) {
 			})
 		}
 
+		if c.usesOperatorDefaultCertificate() {
+			haproxyContainer.Args = append(haproxyContainer.Args, "--default-certificate="+c.cfg.DefaultCertificate)
+		}
+
 		// upsert haproxy and exporter containers
 		obj.Spec.Template.Spec.Containers = core_util.UpsertContainer(obj.Spec.Template.Spec.Containers, haproxyContainer)
 		if exporter, _ := c.getExporterSidecar(); exporter != nil {
@@ -18,6 +18,7 @@ import (
 	"github.com/appscode/kutil/tools/analytics"
 	api "github.com/appscode/voyager/apis/voyager/v1beta1"
 	cs "github.com/appscode/voyager/client/clientset/versioned"
+	api_listers "github.com/appscode/voyager/client/listers/voyager/v1beta1"
 	"github.com/appscode/voyager/pkg/config"
 	"github.com/appscode/voyager/pkg/eventer"
 	_ "github.com/appscode/voyager/third_party/forked/cloudprovider/providers"
@@ -46,27 +47,31 @@ func NewInternalController(
 	promClient pcm.MonitoringV1Interface,
 	serviceLister core_listers.ServiceLister,
 	endpointsLister core_listers.EndpointsLister,
+	certificateLister api_listers.CertificateLister,
 	cfg config.Config,
 	ingress *api.Ingress) Controller {
 	return &internalController{
 		controller: &controller{
-			logger:          log.New(ctx),
-			KubeClient:      kubeClient,
-			WorkloadClient:  workloadClient,
-			CRDClient:       crdClient,
-			VoyagerClient:   extClient,
-			PromClient:      promClient,
-			ServiceLister:   serviceLister,
-			EndpointsLister: endpointsLister,
-			cfg:             cfg,
-			Ingress:         ingress,
-			recorder:        eventer.NewEventRecorder(kubeClient, "voyager operator"),
+			logger:            log.New(ctx),
+			KubeClient:        kubeClient,
+			WorkloadClient:    workloadClient,
+			CRDClient:         crdClient,
+			VoyagerClient:     extClient,
+			PromClient:        promClient,
+			ServiceLister:     serviceLister,
+			EndpointsLister:   endpointsLister,
+			CertificateLister: certificateLister,
+			cfg:               cfg,
+			Ingress:           ingress,
+			recorder:          eventer.NewEventRecorder(kubeClient, "voyager operator"),
 		},
 	}
 }
 
 func (c *internalController) Reconcile() error {
-	if err := c.generateConfig(); err != nil {
+	err := c.generateConfig()
+	c.updateConfigStatus(err)
+	if err != nil {
 		c.recorder.Eventf(
 			c.Ingress.ObjectReference(),
 			core.EventTypeWarning,
@@ -98,6 +103,10 @@ func (c *internalController) Reconcile() error {
 		)
 	}
 
+	if err := c.ensureCertificates(); err != nil {
+		return errors.WithStack(err)
+	}
+
 	// If RBAC is enabled we need to ensure service account
 	if c.cfg.EnableRBAC {
 		c.reconcileRBAC()
@@ -222,6 +231,9 @@ func (c *internalController) Delete() {
 	if err := c.deleteConfigMap(); err != nil {
 		c.logger.Errorln(err)
 	}
+	if err := c.ensureCertificatesDeleted(); err != nil {
+		c.logger.Errorln(err)
+	}
 	if c.cfg.EnableRBAC {
 		if err := c.ensureRBACDeleted(); err != nil {
 			c.logger.Errorln(err)
@@ -429,6 +441,10 @@ func (c *internalController) ensurePods() (kutil.VerbType, error) {
 			})
 		}
 
+		if c.usesOperatorDefaultCertificate() {
+			haproxyContainer.Args = append(haproxyContainer.Args, "--default-certificate="+c.cfg.DefaultCertificate)
+		}
+
 		// upsert haproxy and exporter containers
 		obj.Spec.Template.Spec.Containers = core_util.UpsertContainer(obj.Spec.Template.Spec.Containers, haproxyContainer)
 		if exporter, _ := c.getExporterSidecar(); exporter != nil {
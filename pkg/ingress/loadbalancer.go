@@ -18,8 +18,11 @@ import (
 	core_util "github.com/appscode/kutil/core/v1"
 	meta_util "github.com/appscode/kutil/meta"
 	"github.com/appscode/kutil/tools/analytics"
+	"github.com/appscode/voyager/apis/voyager"
 	api "github.com/appscode/voyager/apis/voyager/v1beta1"
 	cs "github.com/appscode/voyager/client/clientset/versioned"
+	"github.com/appscode/voyager/client/clientset/versioned/typed/voyager/v1beta1/util"
+	api_listers "github.com/appscode/voyager/client/listers/voyager/v1beta1"
 	"github.com/appscode/voyager/pkg/config"
 	"github.com/appscode/voyager/pkg/eventer"
 	_ "github.com/appscode/voyager/third_party/forked/cloudprovider/providers"
@@ -49,27 +52,31 @@ func NewLoadBalancerController(
 	promClient pcm.MonitoringV1Interface,
 	serviceLister core_listers.ServiceLister,
 	endpointsLister core_listers.EndpointsLister,
+	certificateLister api_listers.CertificateLister,
 	cfg config.Config,
 	ingress *api.Ingress) Controller {
 	return &loadBalancerController{
 		controller: &controller{
-			logger:          log.New(ctx),
-			KubeClient:      kubeClient,
-			WorkloadClient:  workloadClient,
-			CRDClient:       crdClient,
-			VoyagerClient:   extClient,
-			PromClient:      promClient,
-			ServiceLister:   serviceLister,
-			EndpointsLister: endpointsLister,
-			cfg:             cfg,
-			Ingress:         ingress,
-			recorder:        eventer.NewEventRecorder(kubeClient, "voyager operator"),
+			logger:            log.New(ctx),
+			KubeClient:        kubeClient,
+			WorkloadClient:    workloadClient,
+			CRDClient:         crdClient,
+			VoyagerClient:     extClient,
+			PromClient:        promClient,
+			ServiceLister:     serviceLister,
+			EndpointsLister:   endpointsLister,
+			CertificateLister: certificateLister,
+			cfg:               cfg,
+			Ingress:           ingress,
+			recorder:          eventer.NewEventRecorder(kubeClient, "voyager operator"),
 		},
 	}
 }
 
 func (c *loadBalancerController) Reconcile() error {
-	if err := c.generateConfig(); err != nil {
+	err := c.generateConfig()
+	c.updateConfigStatus(err)
+	if err != nil {
 		c.recorder.Eventf(
 			c.Ingress.ObjectReference(),
 			core.EventTypeWarning,
@@ -101,6 +108,10 @@ func (c *loadBalancerController) Reconcile() error {
 		)
 	}
 
+	if err := c.ensureCertificates(); err != nil {
+		return errors.WithStack(err)
+	}
+
 	// If RBAC is enabled we need to ensure service account
 	if c.cfg.EnableRBAC {
 		c.reconcileRBAC()
@@ -227,6 +238,9 @@ func (c *loadBalancerController) Delete() {
 	if err := c.deleteConfigMap(); err != nil {
 		c.logger.Errorln(err)
 	}
+	if err := c.ensureCertificatesDeleted(); err != nil {
+		c.logger.Errorln(err)
+	}
 	if c.cfg.EnableRBAC {
 		if err := c.ensureRBACDeleted(); err != nil {
 			c.logger.Errorln(err)
@@ -476,6 +490,10 @@ func (c *loadBalancerController) ensurePods() (kutil.VerbType, error) {
 			})
 		}
 
+		if c.usesOperatorDefaultCertificate() {
+			haproxyContainer.Args = append(haproxyContainer.Args, "--default-certificate="+c.cfg.DefaultCertificate)
+		}
+
 		// upsert haproxy and exporter containers
 		obj.Spec.Template.Spec.Containers = core_util.UpsertContainer(obj.Spec.Template.Spec.Containers, haproxyContainer)
 		if exporter, _ := c.getExporterSidecar(); exporter != nil {
@@ -516,8 +534,10 @@ func (c *loadBalancerController) updateStatus() error {
 			if err != nil {
 				return errors.WithStack(err)
 			}
-			ing.Status.LoadBalancer.Ingress = statuses
-			_, err = c.VoyagerClient.VoyagerV1beta1().Ingresses(c.Ingress.Namespace).Update(ing)
+			_, err = util.UpdateIngressStatus(c.VoyagerClient.VoyagerV1beta1(), ing, func(in *api.IngressStatus) *api.IngressStatus {
+				in.LoadBalancer.Ingress = statuses
+				return in
+			}, voyager.EnableStatusSubresource)
 			if err != nil {
 				return errors.WithStack(err)
 			}
@@ -236,6 +236,7 @@ func (c *controller) rewriteTarget(path string, rewriteRules []string) []string
 }
 
 func (c *controller) generateConfig() error {
+	c.backends = nil
 	if c.Ingress.SSLPassthrough() {
 		if err := c.convertRulesForSSLPassthrough(); err != nil {
 			return err
@@ -288,6 +289,9 @@ func (c *controller) generateConfig() error {
 	if c.Ingress.AcceptProxy() {
 		si.AcceptProxy = true
 	}
+	if c.Ingress.Spec.DefaultCertificate != nil || c.usesOperatorDefaultCertificate() {
+		si.DefaultCertFile = hpi.DefaultCertificateFile
+	}
 
 	userLists := make(map[string]hpi.UserList)
 	var globalBasic *hpi.BasicAuth
@@ -319,21 +323,12 @@ func (c *controller) generateConfig() error {
 
 	dnsResolvers := make(map[string]*api.DNSResolver)
 	if c.Ingress.Spec.Backend != nil {
+		status := newBackendStatus("spec.backend", "", "", c.Ingress.Spec.Backend.IngressBackend)
 		bk, err := c.serviceEndpoints(dnsResolvers, userLists, c.Ingress.Spec.Backend.ServiceName, c.Ingress.Spec.Backend.ServicePort, c.Ingress.Spec.Backend.HostNames)
 		if err != nil {
-			c.recorder.Eventf(
-				c.Ingress.ObjectReference(),
-				core.EventTypeWarning,
-				eventer.EventReasonBackendInvalid,
-				"spec.backend skipped, reason: %s", err,
-			)
+			c.skipBackend(status, err.Error())
 		} else if len(bk.Endpoints) == 0 {
-			c.recorder.Eventf(
-				c.Ingress.ObjectReference(),
-				core.EventTypeWarning,
-				eventer.EventReasonBackendInvalid,
-				"spec.backend skipped, reason: %s", "endpoint not found",
-			)
+			c.skipBackend(status, "endpoint not found")
 		} else {
 			si.DefaultBackend = &hpi.Backend{
 				BasicAuth:        bk.BasicAuth,
@@ -354,6 +349,7 @@ func (c *controller) generateConfig() error {
 			if globalBasic != nil {
 				si.DefaultBackend.BasicAuth = globalBasic
 			}
+			c.resolveBackend(status, si.DefaultBackend)
 		}
 	}
 
@@ -392,8 +388,9 @@ func (c *controller) generateConfig() error {
 		Port    int
 	}
 	type httpInfo struct {
-		OffloadSSL bool
-		Hosts      map[string][]*hpi.HTTPPath
+		OffloadSSL    bool
+		ACMEChallenge bool
+		Hosts         map[string][]*hpi.HTTPPath
 	}
 	httpServices := make(map[hostBinder]*httpInfo)
 	tcpServices := make(map[hostBinder]*hpi.TCPService)
@@ -428,21 +425,12 @@ func (c *controller) generateConfig() error {
 
 			httpPaths := info.Hosts[rule.GetHost()]
 			for pi, path := range rule.HTTP.Paths {
+				status := newBackendStatus(fmt.Sprintf("spec.rules[%d].http.paths[%d]", ri, pi), rule.Host, path.Path, path.Backend.IngressBackend)
 				bk, err := c.serviceEndpoints(dnsResolvers, userLists, path.Backend.ServiceName, path.Backend.ServicePort, path.Backend.HostNames)
 				if err != nil {
-					c.recorder.Eventf(
-						c.Ingress.ObjectReference(),
-						core.EventTypeWarning,
-						eventer.EventReasonBackendInvalid,
-						"spec.rules[%d].http.paths[%d] skipped, reason: %s", ri, pi, err,
-					)
+					c.skipBackend(status, err.Error())
 				} else if len(bk.Endpoints) == 0 {
-					c.recorder.Eventf(
-						c.Ingress.ObjectReference(),
-						core.EventTypeWarning,
-						eventer.EventReasonBackendInvalid,
-						"spec.rules[%d].http.paths[%d] skipped, reason: %s", ri, pi, "endpoint not found",
-					)
+					c.skipBackend(status, "endpoint not found")
 				} else {
 					httpPath := &hpi.HTTPPath{
 						Path: path.Path,
@@ -463,26 +451,18 @@ func (c *controller) generateConfig() error {
 						httpPath.Backend.Name = getBackendName(c.Ingress, path.Backend.IngressBackend)
 						httpPath.Backend.NameGenerated = true
 					}
+					c.resolveBackend(status, httpPath.Backend)
 					httpPaths = append(httpPaths, httpPath)
 				}
 			}
 			info.Hosts[rule.GetHost()] = httpPaths
 		} else if rule.TCP != nil {
+			status := newBackendStatus(fmt.Sprintf("spec.rules[%d].tcp", ri), rule.Host, "", rule.TCP.Backend)
 			bk, err := c.serviceEndpoints(dnsResolvers, userLists, rule.TCP.Backend.ServiceName, rule.TCP.Backend.ServicePort, rule.TCP.Backend.HostNames)
 			if err != nil {
-				c.recorder.Eventf(
-					c.Ingress.ObjectReference(),
-					core.EventTypeWarning,
-					eventer.EventReasonBackendInvalid,
-					"spec.rules[%d].tcp skipped, reason: %s", ri, err,
-				)
+				c.skipBackend(status, err.Error())
 			} else if len(bk.Endpoints) == 0 {
-				c.recorder.Eventf(
-					c.Ingress.ObjectReference(),
-					core.EventTypeWarning,
-					eventer.EventReasonBackendInvalid,
-					"spec.rules[%d].tcp skipped, reason: %s", ri, "endpoint not found",
-				)
+				c.skipBackend(status, "endpoint not found")
 			} else {
 				fr := getFrontendRulesForPort(c.Ingress.Spec.FrontendRules, rule.TCP.Port.IntValue())
 				srv := &hpi.TCPService{
@@ -507,6 +487,7 @@ func (c *controller) generateConfig() error {
 					srv.Backend.Name = getBackendName(c.Ingress, rule.TCP.Backend)
 					srv.Backend.NameGenerated = true
 				}
+				c.resolveBackend(status, srv.Backend)
 
 				if globalTLS != nil {
 					srv.TLSAuth = globalTLS
@@ -721,6 +702,18 @@ func (c *controller) generateConfig() error {
 		}
 	}
 
+	// ACME servers send http-01 challenge requests to port 80
+	if len(httpServices) > 0 {
+		if bk := c.acmeChallengeBackend(userLists); bk != nil {
+			for binder, info := range httpServices {
+				if binder.Port != 80 {
+					continue
+				}
+				info.ACMEChallenge = addACMEChallengePaths(info.Hosts, bk)
+			}
+		}
+	}
+
 	for binder, info := range httpServices {
 		fr := getFrontendRulesForPort(c.Ingress.Spec.FrontendRules, binder.Port)
 		srv := &hpi.HTTPService{
@@ -730,6 +723,7 @@ func (c *controller) generateConfig() error {
 			Port:          binder.Port,
 			FrontendRules: fr.Rules,
 			OffloadSSL:    info.OffloadSSL,
+			ACMEChallenge: info.ACMEChallenge,
 			Hosts:         make([]*hpi.HTTPHost, 0),
 		}
 		for host, paths := range info.Hosts {
@@ -831,11 +825,52 @@ func (c *controller) generateConfig() error {
 		return err
 	} else {
 		c.HAProxyConfig = cfg
+		// backend names are made unique while rendering
+		for i := range c.backends {
+			if c.backends[i].backend != nil {
+				c.backends[i].Backend = c.backends[i].backend.Name
+			}
+		}
 		c.logger.Debugf("Generated haproxy.cfg for Ingress %s/%s", c.Ingress.Namespace, c.Ingress.Name)
 	}
 	return nil
 }
 
+// backendStatus is the result of resolving a backend of the Ingress. backend points to the
+// backend passed to the template, so that its final name can be read after rendering.
+type backendStatus struct {
+	api.IngressBackendStatus
+	backend *hpi.Backend
+}
+
+func newBackendStatus(field, host, path string, b api.IngressBackend) api.IngressBackendStatus {
+	return api.IngressBackendStatus{
+		Field:       field,
+		Host:        host,
+		Path:        path,
+		ServiceName: b.ServiceName,
+		ServicePort: b.ServicePort,
+	}
+}
+
+// skipBackend reports a backend left out of haproxy.cfg via event and Ingress status.
+func (c *controller) skipBackend(status api.IngressBackendStatus, reason string) {
+	c.recorder.Eventf(
+		c.Ingress.ObjectReference(),
+		core.EventTypeWarning,
+		eventer.EventReasonBackendInvalid,
+		"%s skipped, reason: %s", status.Field, reason,
+	)
+	status.Reason = reason
+	c.backends = append(c.backends, backendStatus{IngressBackendStatus: status})
+}
+
+// resolveBackend records a backend added to haproxy.cfg.
+func (c *controller) resolveBackend(status api.IngressBackendStatus, bk *hpi.Backend) {
+	status.Endpoints = int32(len(bk.Endpoints))
+	c.backends = append(c.backends, backendStatus{IngressBackendStatus: status, backend: bk})
+}
+
 func getBasicAuthUsers(userLists map[string]hpi.UserList, sec *core.Secret) ([]string, error) {
 	listNames := make([]string, 0)
 
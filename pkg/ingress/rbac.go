@@ -4,12 +4,17 @@ import (
 	"github.com/appscode/kutil"
 	core_util "github.com/appscode/kutil/core/v1"
 	rbac_util "github.com/appscode/kutil/rbac/v1"
+	"github.com/appscode/voyager/apis/voyager"
 	api "github.com/appscode/voyager/apis/voyager/v1beta1"
 	"github.com/appscode/voyager/pkg/eventer"
+	"github.com/pkg/errors"
 	core "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	rbac "k8s.io/api/rbac/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
 )
 
 func (c *controller) reconcileRBAC() error {
@@ -75,6 +80,18 @@ func (c *controller) reconcileRBAC() error {
 			c.Ingress.OffshootName(),
 		)
 	}
+
+	if err := c.reconcileDefaultCertificateRBAC(); err != nil {
+		c.recorder.Eventf(
+			c.Ingress.ObjectReference(),
+			core.EventTypeWarning,
+			eventer.EventReasonIngressRBACFailed,
+			"Failed to reconcile access to default certificate %s. Reason: %v",
+			c.cfg.DefaultCertificate,
+			err,
+		)
+		return err
+	}
 	return nil
 }
 
@@ -140,6 +157,19 @@ func (c *controller) reconcileRoles() (kutil.VerbType, error) {
 				Verbs:     []string{"create"},
 			},
 		}
+		// haproxy-controller reports ConfigApplied condition in Ingress status, aggregated over the pods of the Ingress.
+		// Without status subresource, that would need write access to the spec of Ingresses, so it is not reported.
+		if voyager.EnableStatusSubresource {
+			in.Rules = append(in.Rules, rbac.PolicyRule{
+				APIGroups: []string{core.GroupName},
+				Resources: []string{"pods"},
+				Verbs:     []string{"list"},
+			}, rbac.PolicyRule{
+				APIGroups: []string{api.SchemeGroupVersion.Group},
+				Resources: []string{"ingresses/status"},
+				Verbs:     []string{"update"},
+			})
+		}
 		return in
 	})
 	return vt, err
@@ -197,3 +227,94 @@ func (c *controller) ensureServiceAccountDeleted() error {
 		ServiceAccounts(c.Ingress.Namespace).
 		Delete(c.Ingress.OffshootName(), &metav1.DeleteOptions{})
 }
+
+// reconcileDefaultCertificateRBAC allows HAProxy pods to read only the default certificate of operator,
+// via a Role and RoleBinding in its namespace. Those can't be owned by the Ingress, since they are
+// in another namespace, so they are deleted once the Ingress no longer uses the default certificate.
+func (c *controller) reconcileDefaultCertificateRBAC() error {
+	if !c.usesOperatorDefaultCertificate() {
+		return c.ensureDefaultCertificateRBACDeleted(metav1.NamespaceNone)
+	}
+	ns, name, err := cache.SplitMetaNamespaceKey(c.cfg.DefaultCertificate)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	// default certificate of operator may have moved to another namespace
+	if err = c.ensureDefaultCertificateRBACDeleted(ns); err != nil {
+		return err
+	}
+	meta := metav1.ObjectMeta{
+		Namespace: ns,
+		Name:      c.Ingress.DefaultCertificateRoleName(),
+	}
+	labels := c.Ingress.OffshootLabels()
+
+	_, _, err = rbac_util.CreateOrPatchRole(c.KubeClient, meta, func(in *rbac.Role) *rbac.Role {
+		in.Labels = labels
+		in.Rules = []rbac.PolicyRule{
+			{
+				APIGroups:     []string{core.GroupName},
+				Resources:     []string{"secrets"},
+				ResourceNames: []string{name},
+				Verbs:         []string{"get"},
+			},
+		}
+		return in
+	})
+	if err != nil {
+		return err
+	}
+	_, _, err = rbac_util.CreateOrPatchRoleBinding(c.KubeClient, meta, func(in *rbac.RoleBinding) *rbac.RoleBinding {
+		in.Labels = labels
+		in.RoleRef = rbac.RoleRef{
+			APIGroup: rbac.GroupName,
+			Kind:     "Role",
+			Name:     meta.Name,
+		}
+		in.Subjects = []rbac.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      c.Ingress.OffshootName(),
+				Namespace: c.Ingress.Namespace,
+			},
+		}
+		return in
+	})
+	return err
+}
+
+// ensureDefaultCertificateRBACDeleted deletes the Role and RoleBinding created by reconcileDefaultCertificateRBAC,
+// except those in keepNamespace. Those are found by offshoot labels, not by the current default certificate
+// of operator, so that they are deleted even if the operator is restarted with another or no default certificate.
+func (c *controller) ensureDefaultCertificateRBACDeleted(keepNamespace string) error {
+	name := c.Ingress.DefaultCertificateRoleName()
+	selector := labels.SelectorFromSet(c.Ingress.OffshootSelector()).String()
+
+	bindings, err := c.KubeClient.RbacV1().RoleBindings(metav1.NamespaceAll).List(metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, binding := range bindings.Items {
+		if binding.Name != name || binding.Namespace == keepNamespace {
+			continue
+		}
+		c.logger.Infof("Deleting RoleBinding %s/%s", binding.Namespace, name)
+		if err = c.KubeClient.RbacV1().RoleBindings(binding.Namespace).Delete(name, &metav1.DeleteOptions{}); err != nil && !kerr.IsNotFound(err) {
+			return errors.WithStack(err)
+		}
+	}
+	roles, err := c.KubeClient.RbacV1().Roles(metav1.NamespaceAll).List(metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, role := range roles.Items {
+		if role.Name != name || role.Namespace == keepNamespace {
+			continue
+		}
+		c.logger.Infof("Deleting Role %s/%s", role.Namespace, name)
+		if err = c.KubeClient.RbacV1().Roles(role.Namespace).Delete(name, &metav1.DeleteOptions{}); err != nil && !kerr.IsNotFound(err) {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package ingress
+
+import (
+	"context"
+	"testing"
+
+	"github.com/appscode/go/log"
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	"github.com/stretchr/testify/assert"
+	rbac "k8s.io/api/rbac/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestEnsureDefaultCertificateRBACDeleted(t *testing.T) {
+	ing := &api.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "test-ingress", Namespace: "default"}}
+	other := &api.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "test-ingress", Namespace: "other"}}
+
+	objects := func(ing *api.Ingress, ns string) []runtime.Object {
+		meta := metav1.ObjectMeta{Name: ing.DefaultCertificateRoleName(), Namespace: ns, Labels: ing.OffshootLabels()}
+		return []runtime.Object{&rbac.Role{ObjectMeta: meta}, &rbac.RoleBinding{ObjectMeta: meta}}
+	}
+	var objs []runtime.Object
+	objs = append(objs, objects(ing, "kube-system")...)
+	objs = append(objs, objects(ing, "certs")...)
+	objs = append(objs, objects(other, "kube-system")...)
+
+	// operator no longer has a default certificate
+	c := &controller{Ingress: ing, KubeClient: fake.NewSimpleClientset(objs...), logger: log.New(context.Background())}
+	assert.NoError(t, c.ensureDefaultCertificateRBACDeleted("certs"))
+
+	exists := func(ns, name string) bool {
+		_, err := c.KubeClient.RbacV1().Roles(ns).Get(name, metav1.GetOptions{})
+		if !kerr.IsNotFound(err) {
+			assert.NoError(t, err)
+		}
+		_, e2 := c.KubeClient.RbacV1().RoleBindings(ns).Get(name, metav1.GetOptions{})
+		assert.Equal(t, kerr.IsNotFound(err), kerr.IsNotFound(e2))
+		return err == nil
+	}
+	assert.False(t, exists("kube-system", ing.DefaultCertificateRoleName()))
+	assert.True(t, exists("certs", ing.DefaultCertificateRoleName()))
+	assert.True(t, exists("kube-system", other.DefaultCertificateRoleName()))
+
+	assert.NoError(t, c.ensureDefaultCertificateRBACDeleted(metav1.NamespaceNone))
+	assert.False(t, exists("certs", ing.DefaultCertificateRoleName()))
+	assert.True(t, exists("kube-system", other.DefaultCertificateRoleName()))
+}
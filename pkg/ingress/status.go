@@ -0,0 +1,101 @@
+package ingress
+
+import (
+	"strings"
+
+	"github.com/appscode/go/log"
+	"github.com/appscode/voyager/apis/voyager"
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	"github.com/appscode/voyager/client/clientset/versioned/typed/voyager/v1beta1/util"
+	"github.com/appscode/voyager/pkg/haproxy/template"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// updateConfigStatus records the result of rendering haproxy.cfg in the status of a voyager Ingress.
+// extensions/v1beta1 Ingresses have no such fields, so their results are reported via events only.
+func (c *controller) updateConfigStatus(renderErr error) {
+	if c.Ingress.APISchema() != api.APISchemaEngress {
+		return
+	}
+	cur, err := c.VoyagerClient.VoyagerV1beta1().Ingresses(c.Ingress.Namespace).Get(c.Ingress.Name, metav1.GetOptions{})
+	if err != nil {
+		log.Errorf("failed to get Ingress %s/%s. Reason: %v", c.Ingress.Namespace, c.Ingress.Name, err)
+		return
+	}
+	backends := make([]api.IngressBackendStatus, 0, len(c.backends))
+	for _, b := range c.backends {
+		backends = append(backends, b.IngressBackendStatus)
+	}
+	_, err = util.UpdateIngressStatus(c.VoyagerClient.VoyagerV1beta1(), cur, func(in *api.IngressStatus) *api.IngressStatus {
+		status := configStatus(*in, c.Ingress.Generation, c.HAProxyConfig, backends, renderErr, metav1.Now())
+		if equality.Semantic.DeepEqual(*in, withEndpointCounts(status, in.Backends)) {
+			return in
+		}
+		return &status
+	}, voyager.EnableStatusSubresource)
+	if err != nil {
+		log.Errorf("failed to update status of Ingress %s/%s. Reason: %v", c.Ingress.Namespace, c.Ingress.Name, err)
+	}
+}
+
+// configStatus returns status with observedGeneration, configHash, backends, ConfigRendered and BackendsResolved
+// conditions set for the haproxy.cfg rendered from the given generation of an Ingress.
+func configStatus(status api.IngressStatus, generation int64, cfg string, backends []api.IngressBackendStatus, renderErr error, now metav1.Time) api.IngressStatus {
+	out := *status.DeepCopy()
+	out.ObservedGeneration = generation
+	if renderErr != nil {
+		out.Conditions = api.SetIngressCondition(out.Conditions, api.IngressCondition{
+			Type:    api.IngressConfigRendered,
+			Status:  core.ConditionFalse,
+			Reason:  "RenderFailed",
+			Message: renderErr.Error(),
+		}, now)
+		return out
+	}
+
+	out.ConfigHash = template.ConfigHash(cfg)
+	out.Backends = backends
+	out.Conditions = api.SetIngressCondition(out.Conditions, api.IngressCondition{
+		Type:    api.IngressConfigRendered,
+		Status:  core.ConditionTrue,
+		Reason:  "Rendered",
+		Message: "Rendered haproxy.cfg " + out.ConfigHash,
+	}, now)
+
+	resolved := api.IngressCondition{
+		Type:    api.IngressBackendsResolved,
+		Status:  core.ConditionTrue,
+		Reason:  "Resolved",
+		Message: "All backends have endpoints",
+	}
+	var skipped []string
+	for _, b := range backends {
+		if b.Reason != "" {
+			skipped = append(skipped, b.Field+": "+b.Reason)
+		}
+	}
+	if len(skipped) > 0 {
+		resolved.Status = core.ConditionFalse
+		resolved.Reason = "BackendsSkipped"
+		resolved.Message = "Skipped " + strings.Join(skipped, "; ")
+	}
+	out.Conditions = api.SetIngressCondition(out.Conditions, resolved, now)
+	return out
+}
+
+// withEndpointCounts returns status with the endpoint counts of backends in cur, unless a backend gains its
+// first or loses its last endpoint. This is used to skip status updates caused by scaling a service alone.
+func withEndpointCounts(status api.IngressStatus, cur []api.IngressBackendStatus) api.IngressStatus {
+	out := *status.DeepCopy()
+	for i, b := range out.Backends {
+		for _, old := range cur {
+			if old.Field == b.Field && old.Backend == b.Backend && (old.Endpoints > 0) == (b.Endpoints > 0) {
+				out.Backends[i].Endpoints = old.Endpoints
+				break
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,67 @@
+package ingress
+
+import (
+	"testing"
+
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	"github.com/appscode/voyager/pkg/haproxy/template"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestConfigStatus(t *testing.T) {
+	now := metav1.Now()
+	cfg := "global\n  daemon"
+
+	status := configStatus(api.IngressStatus{}, 2, cfg, nil, nil, now)
+	assert.Equal(t, int64(2), status.ObservedGeneration)
+	assert.Equal(t, template.ConfigHash(cfg), status.ConfigHash)
+	assert.Equal(t, core.ConditionTrue, api.GetIngressCondition(status.Conditions, api.IngressConfigRendered).Status)
+	assert.Equal(t, core.ConditionTrue, api.GetIngressCondition(status.Conditions, api.IngressBackendsResolved).Status)
+	assert.Equal(t, core.ConditionUnknown, api.GetIngressCondition(status.Conditions, api.IngressReady).Status)
+
+	backends := []api.IngressBackendStatus{
+		{Field: "spec.rules[0].http.paths[0]", ServiceName: "web", ServicePort: intstr.FromInt(80), Backend: "web.default:80", Endpoints: 2},
+		{Field: "spec.rules[0].http.paths[1]", ServiceName: "api", ServicePort: intstr.FromInt(80), Reason: "endpoint not found"},
+	}
+	status = configStatus(status, 3, cfg, backends, nil, now)
+	assert.Equal(t, backends, status.Backends)
+	resolved := api.GetIngressCondition(status.Conditions, api.IngressBackendsResolved)
+	assert.Equal(t, core.ConditionFalse, resolved.Status)
+	assert.Contains(t, resolved.Message, "spec.rules[0].http.paths[1]")
+	assert.NotContains(t, resolved.Message, "spec.rules[0].http.paths[0]")
+
+	// hash of the last rendered config is kept
+	status = configStatus(status, 4, "", nil, errors.New("stats secret not found"), now)
+	assert.Equal(t, int64(4), status.ObservedGeneration)
+	assert.Equal(t, template.ConfigHash(cfg), status.ConfigHash)
+	assert.Equal(t, backends, status.Backends)
+	rendered := api.GetIngressCondition(status.Conditions, api.IngressConfigRendered)
+	assert.Equal(t, core.ConditionFalse, rendered.Status)
+	assert.Equal(t, "stats secret not found", rendered.Message)
+}
+
+func TestWithEndpointCounts(t *testing.T) {
+	now := metav1.Now()
+	cfg := "global\n  daemon"
+	backends := []api.IngressBackendStatus{
+		{Field: "spec.rules[0].http.paths[0]", ServiceName: "web", ServicePort: intstr.FromInt(80), Backend: "web.default:80", Endpoints: 2},
+		{Field: "spec.rules[0].http.paths[1]", ServiceName: "api", ServicePort: intstr.FromInt(80), Backend: "api.default:80", Endpoints: 1},
+	}
+	cur := configStatus(api.IngressStatus{}, 2, cfg, backends, nil, now)
+
+	// scaling a service alone is not written
+	scaled := []api.IngressBackendStatus{backends[0], backends[1]}
+	scaled[0].Endpoints = 5
+	status := configStatus(cur, 2, cfg, scaled, nil, now)
+	assert.Equal(t, cur, withEndpointCounts(status, cur.Backends))
+
+	// losing the last endpoint is
+	scaled[1].Endpoints = 0
+	status = configStatus(cur, 2, cfg, scaled, nil, now)
+	assert.NotEqual(t, cur, withEndpointCounts(status, cur.Backends))
+	assert.Equal(t, int32(2), withEndpointCounts(status, cur.Backends).Backends[0].Endpoints)
+}
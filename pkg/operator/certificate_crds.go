@@ -5,14 +5,16 @@ import (
 	"time"
 
 	"github.com/appscode/go/log"
+	core_util "github.com/appscode/kutil/core/v1"
 	"github.com/appscode/kutil/tools/queue"
+	"github.com/appscode/voyager/apis/voyager"
 	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	"github.com/appscode/voyager/client/clientset/versioned/typed/voyager/v1beta1/util"
 	"github.com/appscode/voyager/pkg/certificate"
 	"github.com/appscode/voyager/pkg/eventer"
-	"github.com/benbjohnson/clock"
 	"github.com/golang/glog"
 	core "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/labels"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -26,6 +28,10 @@ func (op *Operator) initCertificateCRDWatcher() {
 				log.Errorln("Invalid Certificate object")
 				return
 			}
+			if cert.DeletionTimestamp != nil {
+				queue.Enqueue(op.crtQueue.GetQueue(), obj)
+				return
+			}
 			if err := cert.IsValid(op.CloudProvider); err != nil {
 				op.recorder.Eventf(
 					cert.ObjectReference(),
@@ -36,6 +42,13 @@ func (op *Operator) initCertificateCRDWatcher() {
 				)
 				return
 			}
+			if next := cert.Status.NextAttemptTime; next != nil && next.After(time.Now()) {
+				// backing off after a failure, before operator was restarted
+				if key, err := cache.MetaNamespaceKeyFunc(obj); err == nil {
+					op.crtQueue.GetQueue().AddAfter(key, time.Until(next.Time))
+					return
+				}
+			}
 			queue.Enqueue(op.crtQueue.GetQueue(), obj)
 		},
 		UpdateFunc: func(oldObj, newObj interface{}) {
@@ -49,6 +62,12 @@ func (op *Operator) initCertificateCRDWatcher() {
 				log.Errorln("Invalid Certificate object")
 				return
 			}
+			if newCert.DeletionTimestamp != nil {
+				if core_util.HasFinalizer(newCert.ObjectMeta, voyager.GroupName) {
+					queue.Enqueue(op.crtQueue.GetQueue(), newObj)
+				}
+				return
+			}
 			if reflect.DeepEqual(oldCert.Spec, newCert.Spec) {
 				return
 			}
@@ -79,85 +98,166 @@ func (op *Operator) reconcileCertificate(key string) error {
 	}
 	if !exists {
 		glog.Warningf("Certificate %s does not exist anymore\n", key)
-	} else {
-		glog.Infof("Sync/Add/Update for Certificate %s\n", key)
+		op.checkCertificateIngresses(key)
+		return nil
+	}
+	glog.Infof("Sync/Add/Update for Certificate %s\n", key)
 
-		cert := obj.(*api.Certificate).DeepCopy()
-		if _, err := op.MigrateCertificate(cert); err != nil {
-			op.recorder.Eventf(
-				cert.ObjectReference(),
-				core.EventTypeWarning,
-				eventer.EventReasonCertificateMigration,
-				"Reason: %s",
-				err.Error(),
-			)
-			return err
-		}
-		ctrl, err := certificate.NewController(op.KubeClient, op.VoyagerClient, op.Config, cert)
+	cert := obj.(*api.Certificate).DeepCopy()
+	if cert.DeletionTimestamp != nil {
+		return op.finalizeCertificate(key, cert)
+	}
+	if cert, err = op.ensureCertificateFinalizer(cert); err != nil {
+		return err
+	}
+	if cert.IsRateLimited() {
+		glog.Infof("Certificate %s is rate limited, will retry at %s", key, cert.RateLimitedUntil())
+		op.crtQueue.GetQueue().AddAfter(key, time.Until(cert.RateLimitedUntil()))
+		return nil
+	}
+	if _, err := op.MigrateCertificate(cert); err != nil {
+		op.recorder.Eventf(
+			cert.ObjectReference(),
+			core.EventTypeWarning,
+			eventer.EventReasonCertificateMigration,
+			"Reason: %s",
+			err.Error(),
+		)
+		return op.retryCertificate(key, cert, err)
+	}
+	ctrl, err := certificate.NewController(op.KubeClient, op.VoyagerClient, op.Config, cert)
+	if err != nil {
+		op.recorder.Event(
+			cert.ObjectReference(),
+			core.EventTypeWarning,
+			eventer.EventReasonCertificateInvalid,
+			err.Error(),
+		)
+		return op.retryCertificate(key, cert, err)
+	}
+	if err := ctrl.Process(); err != nil {
+		op.recorder.Event(
+			cert.ObjectReference(),
+			core.EventTypeWarning,
+			eventer.EventReasonCertificateInvalid,
+			err.Error(),
+		)
+		return op.retryCertificate(key, cert, err)
+	}
+
+	op.checkCertificateIngresses(key)
+
+	if cert.Status.FailureCount != 0 || cert.Status.NextAttemptTime != nil {
+		_, _, err = util.PatchCertificate(op.VoyagerClient.VoyagerV1beta1(), cert, func(in *api.Certificate) *api.Certificate {
+			in.Status.FailureCount = 0
+			in.Status.NextAttemptTime = nil
+			return in
+		})
 		if err != nil {
-			op.recorder.Event(
-				cert.ObjectReference(),
-				core.EventTypeWarning,
-				eventer.EventReasonCertificateInvalid,
-				err.Error(),
-			)
-			return err
+			log.Errorf("failed to reset failure count of Certificate %s. Reason: %v", key, err)
 		}
-		if err := ctrl.Process(); err != nil {
-			op.recorder.Event(
-				cert.ObjectReference(),
-				core.EventTypeWarning,
-				eventer.EventReasonCertificateInvalid,
-				err.Error(),
-			)
-			return err
+	}
+	next, ok := ctrl.NextRenewalTime()
+	if !ok {
+		glog.Infof("Certificate %s has no certificate to renew", key)
+		return nil
+	}
+	delay := time.Until(next)
+	if delay < minCertificateRetryDelay {
+		delay = minCertificateRetryDelay
+	}
+	glog.Infof("Certificate %s will be checked again in %s", key, delay)
+	op.crtQueue.GetQueue().AddAfter(key, delay)
+	return nil
+}
+
+// checkCertificateIngresses checks the TLS Secrets and Certificates of the Ingresses referring to the Certificate.
+func (op *Operator) checkCertificateIngresses(key string) {
+	items, err := op.ingressesByIndex(ingressByCertificate, key)
+	if err != nil {
+		glog.Errorf("failed to find Ingresses of Certificate %s. Reason: %v", key, err)
+		return
+	}
+	op.checkIngressesTLS(items)
+}
+
+// ensureCertificateFinalizer adds the finalizer to Certificates whose storage is cleaned up on deletion,
+// and removes it once spec.deletionPolicy is set to Retain.
+func (op *Operator) ensureCertificateFinalizer(cert *api.Certificate) (*api.Certificate, error) {
+	cleanup := cert.Spec.DeletionPolicy == api.CertificateDeletionPolicyDelete ||
+		cert.Spec.DeletionPolicy == api.CertificateDeletionPolicyRevokeAndDelete
+	if cleanup == core_util.HasFinalizer(cert.ObjectMeta, voyager.GroupName) {
+		return cert, nil
+	}
+	cert, _, err := util.PatchCertificate(op.VoyagerClient.VoyagerV1beta1(), cert, func(in *api.Certificate) *api.Certificate {
+		if cleanup {
+			in.ObjectMeta = core_util.AddFinalizer(in.ObjectMeta, voyager.GroupName)
+		} else {
+			in.ObjectMeta = core_util.RemoveFinalizer(in.ObjectMeta, voyager.GroupName)
 		}
+		return in
+	})
+	return cert, err
+}
 
-		op.recorder.Eventf(
+// finalizeCertificate cleans up a deleted Certificate as per spec.deletionPolicy and removes its finalizer.
+// Failures are retried, so a Certificate that can't be revoked is deleted only after deletionPolicy is changed.
+func (op *Operator) finalizeCertificate(key string, cert *api.Certificate) error {
+	if !core_util.HasFinalizer(cert.ObjectMeta, voyager.GroupName) {
+		return nil
+	}
+	glog.Infof("Delete for Certificate %s\n", key)
+	if err := certificate.Delete(op.KubeClient, op.VoyagerClient, op.Config, cert); err != nil {
+		op.recorder.Event(
 			cert.ObjectReference(),
-			core.EventTypeNormal,
-			eventer.EventReasonCertificateIssueSuccessful,
-			"Successfully issued certificate",
+			core.EventTypeWarning,
+			eventer.EventReasonCertificateDeleteFailed,
+			err.Error(),
 		)
+		return err
 	}
-	return nil
+	_, _, err := util.PatchCertificate(op.VoyagerClient.VoyagerV1beta1(), cert, func(in *api.Certificate) *api.Certificate {
+		in.ObjectMeta = core_util.RemoveFinalizer(in.ObjectMeta, voyager.GroupName)
+		return in
+	})
+	return err
 }
 
-func (op *Operator) CheckCertificates() {
-	Time := clock.New()
-	for {
-		select {
-		case <-Time.After(time.Minute * 5):
-			result, err := op.crtLister.List(labels.Everything())
-			if err != nil {
-				log.Error(err)
-				continue
-			}
-			for i := range result {
-				cert := result[i]
-				if cert.IsRateLimited() {
-					log.Infoln("skipping certificate %s/%s, since rate limited", cert.Namespace, cert.Name)
-					continue
-				}
-				ctrl, err := certificate.NewController(op.KubeClient, op.VoyagerClient, op.Config, cert)
-				if err != nil {
-					op.recorder.Event(
-						cert.ObjectReference(),
-						core.EventTypeWarning,
-						eventer.EventReasonCertificateInvalid,
-						err.Error(),
-					)
-					return
-				}
-				if err := ctrl.Process(); err != nil {
-					op.recorder.Event(
-						cert.ObjectReference(),
-						core.EventTypeWarning,
-						eventer.EventReasonCertificateInvalid,
-						err.Error(),
-					)
-				}
-			}
-		}
+const (
+	// minCertificateRetryDelay and maxCertificateRetryDelay bound the exponential backoff used to
+	// retry failed certificates.
+	minCertificateRetryDelay = 5 * time.Minute
+	maxCertificateRetryDelay = 12 * time.Hour
+)
+
+// certificateRetryDelay returns the delay before the next attempt after the given number of
+// consecutive failures.
+func certificateRetryDelay(failures int32) time.Duration {
+	delay := minCertificateRetryDelay
+	for i := int32(1); i < failures && delay < maxCertificateRetryDelay; i++ {
+		delay *= 2
 	}
+	if delay > maxCertificateRetryDelay {
+		delay = maxCertificateRetryDelay
+	}
+	return delay
+}
+
+// retryCertificate records the failure in certificate status and requeues it with exponential backoff.
+// It returns nil, so that the queue does not rate limit or drop the key on its own.
+func (op *Operator) retryCertificate(key string, cert *api.Certificate, reason error) error {
+	failures := cert.Status.FailureCount + 1
+	delay := certificateRetryDelay(failures)
+	next := metav1.NewTime(time.Now().Add(delay)).Rfc3339Copy()
+	_, _, err := util.PatchCertificate(op.VoyagerClient.VoyagerV1beta1(), cert, func(in *api.Certificate) *api.Certificate {
+		in.Status.FailureCount = failures
+		in.Status.NextAttemptTime = &next
+		return in
+	})
+	if err != nil {
+		log.Errorf("failed to update status of Certificate %s. Reason: %v", key, err)
+	}
+	log.Errorf("failed to process Certificate %s, will retry in %s. Reason: %v", key, delay, reason)
+	op.crtQueue.GetQueue().AddAfter(key, delay)
+	return nil
 }
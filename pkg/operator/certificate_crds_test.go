@@ -0,0 +1,16 @@
+package operator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCertificateRetryDelay(t *testing.T) {
+	assert.Equal(t, 5*time.Minute, certificateRetryDelay(1))
+	assert.Equal(t, 10*time.Minute, certificateRetryDelay(2))
+	assert.Equal(t, 80*time.Minute, certificateRetryDelay(5))
+	assert.Equal(t, maxCertificateRetryDelay, certificateRetryDelay(9))
+	assert.Equal(t, maxCertificateRetryDelay, certificateRetryDelay(100))
+}
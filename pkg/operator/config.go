@@ -3,6 +3,8 @@ package operator
 import (
 	hooks "github.com/appscode/kubernetes-webhook-util/admission/v1beta1"
 	wcs "github.com/appscode/kubernetes-webhook-util/client/workload/v1"
+	"github.com/appscode/kutil/discovery"
+	"github.com/appscode/voyager/apis/voyager"
 	cs "github.com/appscode/voyager/client/clientset/versioned"
 	voyagerinformers "github.com/appscode/voyager/client/informers/externalversions"
 	"github.com/appscode/voyager/pkg/config"
@@ -43,8 +45,14 @@ func (c *OperatorConfig) New() (*Operator, error) {
 		voyagerInformerFactory: voyagerinformers.NewFilteredSharedInformerFactory(c.VoyagerClient, c.ResyncPeriod, c.WatchNamespace, nil),
 		PromClient:             c.PromClient,
 		recorder:               eventer.NewEventRecorder(c.KubeClient, "voyager operator"),
+		tlsEvents:              map[string]map[string]string{},
 	}
 
+	// status subresource of CRDs is available since Kubernetes 1.11
+	var err error
+	if voyager.EnableStatusSubresource, err = discovery.CheckAPIVersion(c.KubeClient.Discovery(), ">= 1.11"); err != nil {
+		return nil, err
+	}
 	if err := op.ensureCustomResourceDefinitions(); err != nil {
 		return nil, err
 	}
@@ -61,6 +69,9 @@ func (c *OperatorConfig) New() (*Operator, error) {
 	op.initServiceMonitorWatcher()
 	op.initNamespaceWatcher()
 	op.initCertificateCRDWatcher()
+	if err := op.addIndexers(); err != nil {
+		return nil, err
+	}
 
 	return op, nil
 }
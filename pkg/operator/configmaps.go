@@ -33,7 +33,7 @@ func (op *Operator) reconcileConfigMap(key string) error {
 
 // requeue ingress if user deletes haproxy-configmap
 func (op *Operator) restoreConfigMap(name, ns string) error {
-	items, err := op.listIngresses()
+	items, err := op.ingressesByIndex(ingressByOffshoot, ns+"/"+name)
 	if err != nil {
 		return err
 	}
@@ -34,7 +34,7 @@ func (op *Operator) reconcileDeployment(key string) error {
 
 // requeue ingress if user deletes haproxy-deployment
 func (op *Operator) restoreDeployment(name, ns string) error {
-	items, err := op.listIngresses()
+	items, err := op.ingressesByIndex(ingressByOffshoot, ns+"/"+name)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,160 @@
+package operator
+
+import (
+	"strings"
+
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Indexers of Ingresses, so that changes to a referred object map directly to the affected Ingresses
+// instead of scanning every Ingress.
+const (
+	// ingressByOffshoot indexes Ingresses by <namespace>/<name> of their offshoot Service, ConfigMap, Deployment and DaemonSet.
+	ingressByOffshoot = "offshoot"
+	// ingressByBackendService indexes Ingresses by <name>.<namespace> of their backend Services, as in Ingress.HasBackendService.
+	ingressByBackendService = "backendService"
+	// ingressBySecret indexes Ingresses by <namespace>/<name> of their auth Secret, TLS Secrets and default certificate Secret.
+	ingressBySecret = "secret"
+	// ingressByCertificate indexes Ingresses by <namespace>/<name> of the Certificates they refer to.
+	ingressByCertificate = "certificate"
+
+	// serviceByAuthSecret indexes Services by <namespace>/<name> of the Secret in their auth-secret annotation.
+	serviceByAuthSecret = "authSecret"
+	// certificateBySecret indexes Certificates by <namespace>/<name> of the Secret they are stored in.
+	certificateBySecret = "secret"
+)
+
+func (op *Operator) addIndexers() error {
+	ingressIndexers := cache.Indexers{
+		ingressByOffshoot:       ingressIndexFunc(indexIngressByOffshoot),
+		ingressByBackendService: ingressIndexFunc(indexIngressByBackendService),
+		ingressBySecret:         ingressIndexFunc(op.indexIngressBySecret),
+		ingressByCertificate:    ingressIndexFunc(indexIngressByCertificate),
+	}
+	if err := op.engInformer.AddIndexers(ingressIndexers); err != nil {
+		return errors.Wrap(err, "failed to add indexers of Ingress crds")
+	}
+	if err := op.ingInformer.AddIndexers(ingressIndexers); err != nil {
+		return errors.Wrap(err, "failed to add indexers of Ingresses")
+	}
+	if err := op.svcInformer.AddIndexers(cache.Indexers{serviceByAuthSecret: indexServiceByAuthSecret}); err != nil {
+		return errors.Wrap(err, "failed to add indexers of Services")
+	}
+	if err := op.crtInformer.AddIndexers(cache.Indexers{certificateBySecret: indexCertificateBySecret}); err != nil {
+		return errors.Wrap(err, "failed to add indexers of Certificates")
+	}
+	return nil
+}
+
+// ingressIndexFunc adapts fn to index Ingresses of both api schemas. Ingresses that can't be
+// converted are not indexed, as they are left out of listIngresses too.
+func ingressIndexFunc(fn func(ing *api.Ingress) []string) cache.IndexFunc {
+	return func(obj interface{}) ([]string, error) {
+		switch ing := obj.(type) {
+		case *api.Ingress:
+			return fn(ing), nil
+		case *extensions.Ingress:
+			engress, err := api.NewEngressFromIngress(ing)
+			if err != nil {
+				return nil, nil
+			}
+			return fn(engress), nil
+		}
+		return nil, errors.Errorf("unexpected object of type %T", obj)
+	}
+}
+
+func indexIngressByOffshoot(ing *api.Ingress) []string {
+	return []string{ing.Namespace + "/" + ing.OffshootName()}
+}
+
+func indexIngressByBackendService(ing *api.Ingress) []string {
+	services := ing.BackendServices()
+	keys := make([]string, 0, len(services))
+	for fqn := range services {
+		keys = append(keys, fqn)
+	}
+	return keys
+}
+
+// indexIngressBySecret indexes the Secrets checked by Ingress.UsesAuthSecret and isDefaultCertificate,
+// and the TLS Secrets checked by checkIngressTLS.
+func (op *Operator) indexIngressBySecret(ing *api.Ingress) []string {
+	var keys []string
+	if name := ing.AuthSecretName(); name != "" {
+		keys = append(keys, ing.Namespace+"/"+name)
+	}
+	for _, tls := range ing.Spec.TLS {
+		if tls.SecretName != "" { // not migrated yet
+			keys = append(keys, ing.Namespace+"/"+tls.SecretName)
+		} else if tls.Ref != nil && tls.Ref.Name != "" && (tls.Ref.Kind == "" || strings.EqualFold(tls.Ref.Kind, "Secret")) {
+			keys = append(keys, ing.Namespace+"/"+tls.Ref.Name)
+		}
+	}
+	if ref := ing.Spec.DefaultCertificate; ref != nil {
+		if !strings.EqualFold(ref.Kind, api.ResourceKindCertificate) {
+			keys = append(keys, ing.Namespace+"/"+ref.Name)
+		}
+	} else if len(ing.Spec.TLS) > 0 && op.DefaultCertificate != "" {
+		keys = append(keys, op.DefaultCertificate)
+	}
+	return keys
+}
+
+func indexIngressByCertificate(ing *api.Ingress) []string {
+	var keys []string
+	if ref := ing.Spec.DefaultCertificate; ref != nil && strings.EqualFold(ref.Kind, api.ResourceKindCertificate) {
+		keys = append(keys, ing.Namespace+"/"+ref.Name)
+	}
+	for _, tls := range ing.Spec.TLS {
+		if tls.Ref != nil && strings.EqualFold(tls.Ref.Kind, api.ResourceKindCertificate) {
+			keys = append(keys, ing.Namespace+"/"+tls.Ref.Name)
+		}
+	}
+	return keys
+}
+
+func indexServiceByAuthSecret(obj interface{}) ([]string, error) {
+	svc, ok := obj.(*core.Service)
+	if !ok {
+		return nil, errors.Errorf("unexpected object of type %T", obj)
+	}
+	if name := svc.Annotations[api.AuthSecret]; name != "" {
+		return []string{svc.Namespace + "/" + name}, nil
+	}
+	return nil, nil
+}
+
+func indexCertificateBySecret(obj interface{}) ([]string, error) {
+	crd, ok := obj.(*api.Certificate)
+	if !ok {
+		return nil, errors.Errorf("unexpected object of type %T", obj)
+	}
+	return []string{crd.Namespace + "/" + crd.SecretName()}, nil
+}
+
+// ingressesByIndex returns the Ingresses of both api schemas indexed under indexKey by indexName.
+func (op *Operator) ingressesByIndex(indexName, indexKey string) ([]api.Ingress, error) {
+	engList, err := op.engInformer.GetIndexer().ByIndex(indexName, indexKey)
+	if err != nil {
+		return nil, err
+	}
+	ingList, err := op.ingInformer.GetIndexer().ByIndex(indexName, indexKey)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]api.Ingress, 0, len(engList)+len(ingList))
+	for _, obj := range engList {
+		items = append(items, *obj.(*api.Ingress))
+	}
+	for _, obj := range ingList {
+		if e, err := api.NewEngressFromIngress(obj.(*extensions.Ingress)); err == nil {
+			items = append(items, *e)
+		}
+	}
+	return items, nil
+}
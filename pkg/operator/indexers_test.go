@@ -0,0 +1,140 @@
+package operator
+
+import (
+	"sort"
+	"testing"
+
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	vfake "github.com/appscode/voyager/client/clientset/versioned/fake"
+	voyagerinformers "github.com/appscode/voyager/client/informers/externalversions"
+	"github.com/appscode/voyager/pkg/config"
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newIndexedOperator(t *testing.T) *Operator {
+	op := &Operator{
+		Config: config.Config{
+			DefaultCertificate: "kube-system/default-tls",
+			OperatorNamespace:  "kube-system",
+			OperatorService:    "voyager-operator",
+		},
+		kubeInformerFactory:    informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0),
+		voyagerInformerFactory: voyagerinformers.NewSharedInformerFactory(vfake.NewSimpleClientset(), 0),
+	}
+	op.engInformer = op.voyagerInformerFactory.Voyager().V1beta1().Ingresses().Informer()
+	op.ingInformer = op.kubeInformerFactory.Extensions().V1beta1().Ingresses().Informer()
+	op.svcInformer = op.kubeInformerFactory.Core().V1().Services().Informer()
+	op.crtInformer = op.voyagerInformerFactory.Voyager().V1beta1().Certificates().Informer()
+	op.engLister = op.voyagerInformerFactory.Voyager().V1beta1().Ingresses().Lister()
+	op.ingLister = op.kubeInformerFactory.Extensions().V1beta1().Ingresses().Lister()
+	if err := op.addIndexers(); err != nil {
+		t.Fatal(err)
+	}
+	return op
+}
+
+func ingressNames(items []api.Ingress) []string {
+	names := make([]string, 0, len(items))
+	for _, ing := range items {
+		names = append(names, ing.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestIngressIndexers(t *testing.T) {
+	op := newIndexedOperator(t)
+
+	engresses := []*api.Ingress{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "auth", Namespace: "default", Annotations: map[string]string{api.AuthSecret: "basic-auth"}},
+			Spec: api.IngressSpec{
+				Backend: &api.HTTPIngressBackend{IngressBackend: api.IngressBackend{ServiceName: "web", ServicePort: intstr.FromInt(80)}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "cert", Namespace: "default"},
+			Spec: api.IngressSpec{
+				DefaultCertificate: &api.LocalTypedReference{Kind: api.ResourceKindCertificate, Name: "wildcard"},
+				Rules: []api.IngressRule{{IngressRuleValue: api.IngressRuleValue{TCP: &api.TCPIngressRuleValue{
+					Backend: api.IngressBackend{ServiceName: "db.storage", ServicePort: intstr.FromInt(5432)},
+				}}}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "other"},
+			Spec: api.IngressSpec{TLS: []api.IngressTLS{
+				{Hosts: []string{"example.com"}},
+				{Hosts: []string{"www.example.com"}, Ref: &api.LocalTypedReference{Kind: "Secret", Name: "www-tls"}},
+			}},
+		},
+	}
+	for _, ing := range engresses {
+		assert.NoError(t, op.engInformer.GetIndexer().Add(ing))
+	}
+	assert.NoError(t, op.ingInformer.GetIndexer().Add(&extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: "default"},
+		Spec: extensions.IngressSpec{
+			Backend: &extensions.IngressBackend{ServiceName: "web", ServicePort: intstr.FromInt(80)},
+		},
+	}))
+	assert.NoError(t, op.crtInformer.GetIndexer().Add(&api.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: "wildcard", Namespace: "default"},
+	}))
+	assert.NoError(t, op.svcInformer.GetIndexer().Add(&core.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Annotations: map[string]string{api.AuthSecret: "web-auth"}},
+	}))
+
+	items, err := op.ingressesByIndex(ingressByBackendService, "web.default")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"auth", "legacy"}, ingressNames(items))
+
+	items, err = op.ingressesByIndex(ingressByBackendService, "db.storage")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cert"}, ingressNames(items))
+
+	// every Ingress routes http-01 challenges to the operator service
+	items, err = op.ingressesByBackendService("web", "default")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"auth", "legacy"}, ingressNames(items))
+
+	items, err = op.ingressesByBackendService("voyager-operator", "kube-system")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"auth", "cert", "legacy", "tls"}, ingressNames(items))
+
+	items, err = op.ingressesByIndex(ingressByOffshoot, "default/"+api.VoyagerPrefix+"legacy")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"legacy"}, ingressNames(items))
+
+	secret := func(ns, name string) *core.Secret {
+		return &core.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns}}
+	}
+	items, err = op.ingressesBySecret(secret("default", "basic-auth"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"auth"}, ingressNames(items))
+
+	items, err = op.ingressesBySecret(secret("default", "tls-wildcard"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cert"}, ingressNames(items))
+
+	items, err = op.ingressesBySecret(secret("kube-system", "default-tls"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tls"}, ingressNames(items))
+
+	items, err = op.ingressesBySecret(secret("other", "www-tls"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tls"}, ingressNames(items))
+
+	items, err = op.ingressesBySecret(secret("other", "basic-auth"))
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+
+	assert.True(t, op.IngressServiceUsesAuthSecret(engresses[0], secret("default", "web-auth")))
+	assert.False(t, op.IngressServiceUsesAuthSecret(engresses[1], secret("default", "web-auth")))
+}
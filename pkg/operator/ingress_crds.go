@@ -80,7 +80,7 @@ func (op *Operator) reconcileEngress(key string) error {
 
 	engress := obj.(*api.Ingress).DeepCopy()
 	engress.Migrate()
-	ctrl := ingress.NewController(NewID(context.Background()), op.KubeClient, op.WorkloadClient, op.CRDClient, op.VoyagerClient, op.PromClient, op.svcLister, op.epLister, op.Config, engress)
+	ctrl := ingress.NewController(NewID(context.Background()), op.KubeClient, op.WorkloadClient, op.CRDClient, op.VoyagerClient, op.PromClient, op.svcLister, op.epLister, op.crtLister, op.Config, engress)
 
 	if engress.DeletionTimestamp != nil {
 		if core_util.HasFinalizer(engress.ObjectMeta, voyager.GroupName) {
@@ -100,6 +100,7 @@ func (op *Operator) reconcileEngress(key string) error {
 			})
 		}
 		if engress.ShouldHandleIngress(op.IngressClass) {
+			op.checkIngressesTLS([]api.Ingress{*engress})
 			return ctrl.Reconcile()
 		} else {
 			log.Infof("%s %s/%s does not match ingress class", engress.APISchema(), engress.Namespace, engress.Name)
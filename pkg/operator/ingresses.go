@@ -93,7 +93,7 @@ func (op *Operator) reconcileIngress(key string) error {
 		return nil
 	}
 
-	ctrl := ingress.NewController(NewID(context.Background()), op.KubeClient, op.WorkloadClient, op.CRDClient, op.VoyagerClient, op.PromClient, op.svcLister, op.epLister, op.Config, engress)
+	ctrl := ingress.NewController(NewID(context.Background()), op.KubeClient, op.WorkloadClient, op.CRDClient, op.VoyagerClient, op.PromClient, op.svcLister, op.epLister, op.crtLister, op.Config, engress)
 
 	if ing.DeletionTimestamp != nil {
 		if core_util.HasFinalizer(ing.ObjectMeta, voyager.GroupName) {
@@ -113,6 +113,7 @@ func (op *Operator) reconcileIngress(key string) error {
 			})
 		}
 		if engress.ShouldHandleIngress(op.IngressClass) {
+			op.checkIngressesTLS([]api.Ingress{*engress})
 			return ctrl.Reconcile()
 		} else {
 			log.Infof("%s %s/%s does not match ingress class", engress.APISchema(), engress.Namespace, engress.Name)
@@ -0,0 +1,138 @@
+package operator
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/appscode/go/log"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+var (
+	leaderDesc = prometheus.NewDesc(
+		"voyager_operator_leader",
+		"1 if this operator replica is the leader, 0 otherwise. The leader label is the identity of the current leader.",
+		[]string{"leader"}, nil,
+	)
+
+	leaderStatus = &leaderState{}
+)
+
+func init() {
+	prometheus.MustRegister(leaderStatus)
+}
+
+// leaderState is the result of leader election as observed by this replica.
+type leaderState struct {
+	mu       sync.RWMutex
+	identity string // identity of this replica
+	leader   string // identity of the current leader, empty until observed
+}
+
+func (s *leaderState) Describe(ch chan<- *prometheus.Desc) {
+	ch <- leaderDesc
+}
+
+func (s *leaderState) Collect(ch chan<- prometheus.Metric) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.leader == "" {
+		return
+	}
+	isLeader := 0.0
+	if s.leader == s.identity {
+		isLeader = 1
+	}
+	ch <- prometheus.MustNewConstMetric(leaderDesc, prometheus.GaugeValue, isLeader, s.leader)
+}
+
+func (s *leaderState) set(identity, leader string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.identity, s.leader = identity, leader
+}
+
+// ServeHTTP reports the identity of this replica and the current leader.
+func (s *leaderState) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		Identity string `json:"identity"`
+		Leader   string `json:"leader"`
+		IsLeader bool   `json:"isLeader"`
+	}{s.identity, s.leader, s.leader != "" && s.leader == s.identity})
+}
+
+// leaderLockName is the name of the ConfigMap used as leader election lock by replicas of the operator.
+func (op *Operator) leaderLockName() string {
+	return op.OperatorService + "-leader-lock"
+}
+
+// acmeChallengesName is the name of the ConfigMap used to share http-01 challenges among replicas of the operator.
+func (op *Operator) acmeChallengesName() string {
+	return op.OperatorService + "-acme-challenges"
+}
+
+// runLeaderElection calls lead once this replica acquires the leader lease. Work queues can't be
+// restarted, so the operator exits when it loses the lease and leaves it to another replica.
+func (op *Operator) runLeaderElection(stopCh <-chan struct{}, lead func(stopCh <-chan struct{})) error {
+	identity, err := os.Hostname()
+	if err != nil {
+		return errors.Wrap(err, "failed to detect leader election identity")
+	}
+	leaderStatus.set(identity, "")
+
+	lock, err := resourcelock.New(
+		resourcelock.ConfigMapsResourceLock,
+		op.OperatorNamespace,
+		op.leaderLockName(),
+		op.KubeClient.CoreV1(),
+		resourcelock.ResourceLockConfig{
+			Identity:      identity,
+			EventRecorder: op.recorder,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:          lock,
+		LeaseDuration: op.LeaderElectionLeaseDuration,
+		RenewDeadline: op.LeaderElectionRenewDeadline,
+		RetryPeriod:   op.LeaderElectionRetryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(stop <-chan struct{}) {
+				log.Infof("%s acquired leader lease %s/%s", identity, op.OperatorNamespace, op.leaderLockName())
+				ch := make(chan struct{})
+				go func() {
+					select {
+					case <-stop:
+					case <-stopCh:
+					}
+					close(ch)
+				}()
+				lead(ch)
+			},
+			OnStoppedLeading: func() {
+				log.Fatalf("%s lost leader lease %s/%s", identity, op.OperatorNamespace, op.leaderLockName())
+			},
+			OnNewLeader: func(leader string) {
+				log.Infof("%s is the leader of voyager operator", leader)
+				leaderStatus.set(identity, leader)
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	elector.Run()
+	return nil
+}
@@ -0,0 +1,27 @@
+package operator
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaderStateServeHTTP(t *testing.T) {
+	s := &leaderState{}
+	s.set("voyager-a", "")
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+	assert.JSONEq(t, `{"identity":"voyager-a","leader":"","isLeader":false}`, w.Body.String())
+
+	s.set("voyager-a", "voyager-b")
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+	assert.JSONEq(t, `{"identity":"voyager-a","leader":"voyager-b","isLeader":false}`, w.Body.String())
+
+	s.set("voyager-a", "voyager-a")
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+	assert.JSONEq(t, `{"identity":"voyager-a","leader":"voyager-a","isLeader":true}`, w.Body.String())
+}
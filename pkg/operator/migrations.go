@@ -50,7 +50,7 @@ func (op *Operator) MigrateCertificate(cert *api.Certificate) (bool, error) {
 		}
 	}
 
-	if cert.Spec.ACMEUserSecretName == "" {
+	if cert.UsesACME() && cert.Spec.ACMEUserSecretName == "" {
 		cert.Spec.ACMEUserSecretName = "acme-" + cert.Name
 		migrate = true
 	}
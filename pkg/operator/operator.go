@@ -2,6 +2,8 @@ package operator
 
 import (
 	"net/http"
+	"os"
+	"sync"
 
 	"github.com/appscode/go/log"
 	wcs "github.com/appscode/kubernetes-webhook-util/client/workload/v1"
@@ -12,6 +14,7 @@ import (
 	cs "github.com/appscode/voyager/client/clientset/versioned"
 	voyagerinformers "github.com/appscode/voyager/client/informers/externalversions"
 	api_listers "github.com/appscode/voyager/client/listers/voyager/v1beta1"
+	"github.com/appscode/voyager/pkg/certificate/providers"
 	"github.com/appscode/voyager/pkg/config"
 	prom "github.com/coreos/prometheus-operator/pkg/client/monitoring/v1"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -101,6 +104,10 @@ type Operator struct {
 	svcQueue    *queue.Worker
 	svcInformer cache.SharedIndexInformer
 	svcLister   core_listers.ServiceLister
+
+	// TLS Secret monitor, last event message per Ingress and TLS Secret
+	tlsEventsLock sync.Mutex
+	tlsEvents     map[string]map[string]string
 }
 
 func (op *Operator) ensureCustomResourceDefinitions() error {
@@ -109,6 +116,7 @@ func (op *Operator) ensureCustomResourceDefinitions() error {
 	crds := []*kext.CustomResourceDefinition{
 		api.Ingress{}.CustomResourceDefinition(),
 		api.Certificate{}.CustomResourceDefinition(),
+		api.ClusterIssuer{}.CustomResourceDefinition(),
 	}
 	return apiext_util.RegisterCRDs(op.CRDClient, crds)
 }
@@ -116,8 +124,6 @@ func (op *Operator) ensureCustomResourceDefinitions() error {
 func (op *Operator) RunInformers(stopCh <-chan struct{}) {
 	defer runtime.HandleCrash()
 
-	go op.CheckCertificates()
-
 	log.Infoln("Starting Voyager controller")
 	op.kubeInformerFactory.Start(stopCh)
 	op.voyagerInformerFactory.Start(stopCh)
@@ -157,12 +163,39 @@ func (op *Operator) RunInformers(stopCh <-chan struct{}) {
 	if op.smonInformer != nil {
 		op.smonQueue.Run(stopCh)
 	}
+	go op.runTLSSecretMonitor(stopCh)
 
 	<-stopCh
 	log.Infoln("Stopping Stash controller")
 }
 
 func (w *Operator) Run(stopCh <-chan struct{}) {
+	// leader presents http-01 challenges, but any replica may receive the requests of ACME servers
+	providers.DefaultHTTPProvider().Share(w.KubeClient, w.OperatorNamespace, w.acmeChallengesName(), stopCh)
+
+	if w.LeaderElection {
+		go func() {
+			if err := w.runLeaderElection(stopCh, w.lead); err != nil {
+				log.Fatalln("Failed leader election:", err)
+			}
+		}()
+	} else {
+		if identity, err := os.Hostname(); err == nil {
+			leaderStatus.set(identity, identity)
+		}
+		go w.lead(stopCh)
+	}
+
+	m := pat.New()
+	m.Get("/metrics", promhttp.Handler())
+	m.Get("/healthz", leaderStatus)
+	http.Handle("/", m)
+	log.Infoln("Listening on", w.OpsAddress)
+	log.Fatal(http.ListenAndServe(w.OpsAddress, nil))
+}
+
+// lead migrates objects and runs the work queues. Only the leader replica of the operator calls it.
+func (w *Operator) lead(stopCh <-chan struct{}) {
 	// https://github.com/appscode/voyager/issues/346
 	err := w.ValidateIngress()
 	if err != nil {
@@ -179,13 +212,7 @@ func (w *Operator) Run(stopCh <-chan struct{}) {
 	// https://github.com/appscode/voyager/issues/446
 	w.PurgeOffshootsDaemonSet()
 
-	go w.RunInformers(stopCh)
-
-	m := pat.New()
-	m.Get("/metrics", promhttp.Handler())
-	http.Handle("/", m)
-	log.Infoln("Listening on", w.OpsAddress)
-	log.Fatal(http.ListenAndServe(w.OpsAddress, nil))
+	w.RunInformers(stopCh)
 }
 
 func (op *Operator) listIngresses() ([]api.Ingress, error) {
@@ -2,6 +2,7 @@ package operator
 
 import (
 	"reflect"
+	"strings"
 
 	"github.com/appscode/go/log"
 	"github.com/appscode/kutil/tools/queue"
@@ -9,7 +10,8 @@ import (
 	_ "github.com/appscode/voyager/third_party/forked/cloudprovider/providers"
 	"github.com/golang/glog"
 	core "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/labels"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -33,17 +35,18 @@ func (op *Operator) reconcileSecret(key string) error {
 	if exists {
 		glog.Infof("Sync/Add/Update for Secret %s\n", key)
 		secret := obj.(*core.Secret).DeepCopy()
-		// Secret DataChanged. We need to list all Ingress and check which of
-		// those ingress uses this secret as basic auth secret.
-		items, err := op.listIngresses()
+		// Secret DataChanged. We need to check which of the Ingresses referring to
+		// this secret uses it as basic auth secret or default certificate.
+		items, err := op.ingressesBySecret(secret)
 		if err != nil {
 			return err
 		}
+		op.checkIngressesTLS(items)
 		for i := range items {
 			ing := &items[i]
 			if ing.DeletionTimestamp == nil &&
 				(ing.ShouldHandleIngress(op.IngressClass) || op.IngressServiceUsesAuthSecret(ing, secret)) {
-				if ing.UsesAuthSecret(secret.Namespace, secret.Name) {
+				if ing.UsesAuthSecret(secret.Namespace, secret.Name) || op.isDefaultCertificate(ing, secret) {
 					if key, err := cache.MetaNamespaceKeyFunc(ing); err != nil {
 						return err
 					} else {
@@ -53,24 +56,84 @@ func (op *Operator) reconcileSecret(key string) error {
 				}
 			}
 		}
+	} else {
+		// deleted TLS Secrets are reported by the Ingresses still referring to them
+		ns, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			return err
+		}
+		items, err := op.ingressesBySecret(&core.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name}})
+		if err != nil {
+			return err
+		}
+		op.checkIngressesTLS(items)
 	}
 	return nil
 }
 
+// ingressesBySecret returns the Ingresses referring to secret directly or via a Certificate stored in it.
+func (op *Operator) ingressesBySecret(secret *core.Secret) ([]tapi.Ingress, error) {
+	key := secret.Namespace + "/" + secret.Name
+	items, err := op.ingressesByIndex(ingressBySecret, key)
+	if err != nil {
+		return nil, err
+	}
+	crds, err := op.crtInformer.GetIndexer().ByIndex(certificateBySecret, key)
+	if err != nil {
+		return nil, err
+	}
+	if len(crds) == 0 {
+		return items, nil
+	}
+
+	found := sets.NewString()
+	for _, ing := range items {
+		found.Insert(ing.APISchema() + "/" + ing.Namespace + "/" + ing.Name)
+	}
+	for _, obj := range crds {
+		crd := obj.(*tapi.Certificate)
+		engs, err := op.ingressesByIndex(ingressByCertificate, crd.Namespace+"/"+crd.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, ing := range engs {
+			if id := ing.APISchema() + "/" + ing.Namespace + "/" + ing.Name; !found.Has(id) {
+				found.Insert(id)
+				items = append(items, ing)
+			}
+		}
+	}
+	return items, nil
+}
+
+// isDefaultCertificate returns true if secret is used as default certificate by ing.
+// HAProxy pods read operator's default certificate from its namespace, which they do not watch. So changes
+// to it must be propagated via the hash of it in the ConfigMap of Ingress.
+func (op *Operator) isDefaultCertificate(ing *tapi.Ingress, secret *core.Secret) bool {
+	if ref := ing.Spec.DefaultCertificate; ref != nil {
+		if ing.Namespace != secret.Namespace {
+			return false
+		}
+		if strings.EqualFold(ref.Kind, tapi.ResourceKindCertificate) {
+			crd, err := op.crtLister.Certificates(ing.Namespace).Get(ref.Name)
+			return err == nil && crd.SecretName() == secret.Name
+		}
+		return ref.Name == secret.Name
+	}
+	return len(ing.Spec.TLS) > 0 && op.DefaultCertificate == secret.Namespace+"/"+secret.Name
+}
+
 func (op *Operator) IngressServiceUsesAuthSecret(ing *tapi.Ingress, secret *core.Secret) bool {
-	svcs, err := op.svcLister.List(labels.Everything())
+	svcs, err := op.svcInformer.GetIndexer().ByIndex(serviceByAuthSecret, secret.Namespace+"/"+secret.Name)
 	if err != nil {
 		log.Errorln(err)
 		return false
 	}
 
-	for _, svc := range svcs {
+	for _, obj := range svcs {
+		svc := obj.(*core.Service)
 		if ing.HasBackendService(svc.Name, svc.Namespace) {
-			if svc.Annotations != nil {
-				if svc.Annotations[tapi.AuthSecret] == secret.Name && svc.Namespace == secret.Namespace {
-					return true
-				}
-			}
+			return true
 		}
 	}
 	return false
@@ -47,7 +47,7 @@ func (op *Operator) reconcileService(key string) error {
 // requeue ingress if offshoot-service deleted
 // return true if service is offshoot for any ingress
 func (op *Operator) restoreIngressService(name, ns string) (bool, error) {
-	items, err := op.listIngresses()
+	items, err := op.ingressesByIndex(ingressByOffshoot, ns+"/"+name)
 	if err == nil {
 		for i := range items {
 			ing := &items[i]
@@ -69,22 +69,42 @@ func (op *Operator) restoreIngressService(name, ns string) (bool, error) {
 
 // requeue ingress if add/delete/update of backend-service
 func (op *Operator) updateHAProxyConfig(name, ns string) error {
-	items, err := op.listIngresses()
+	items, err := op.ingressesByBackendService(name, ns)
 	if err != nil {
 		return err
 	}
 	for i := range items {
-		ing := &items[i]
+		if key, err := cache.MetaNamespaceKeyFunc(&items[i]); err == nil {
+			op.getIngressQueue(items[i].APISchema()).Add(key)
+			log.Infof("Add/Delete/Update of backend service %s/%s, Ingress %s re-queued for update", ns, name, key)
+		}
+	}
+	return nil
+}
+
+// ingressesByBackendService returns the handled Ingresses that route to the given Service. Every
+// Ingress routes http-01 challenge requests to the operator service, see acmeChallengeBackend.
+func (op *Operator) ingressesByBackendService(name, ns string) ([]api.Ingress, error) {
+	var items []api.Ingress
+	var err error
+	operator := name == op.OperatorService && ns == op.OperatorNamespace
+	if operator {
+		items, err = op.listIngresses()
+	} else {
+		items, err = op.ingressesByIndex(ingressByBackendService, name+"."+ns)
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := items[:0]
+	for _, ing := range items {
 		if ing.DeletionTimestamp == nil &&
 			ing.ShouldHandleIngress(op.IngressClass) &&
-			ing.HasBackendService(name, ns) {
-			if key, err := cache.MetaNamespaceKeyFunc(ing); err == nil {
-				op.getIngressQueue(ing.APISchema()).Add(key)
-				log.Infof("Add/Delete/Update of backend service %s/%s, Ingress %s re-queued for update", ns, name, key)
-			}
+			(operator || ing.HasBackendService(name, ns)) {
+			out = append(out, ing)
 		}
 	}
-	return nil
+	return out, nil
 }
 
 func (op *Operator) findOrigin(meta metav1.ObjectMeta) (*api.Ingress, error) {
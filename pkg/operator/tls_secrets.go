@@ -0,0 +1,441 @@
+package operator
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/appscode/voyager/apis/voyager"
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	"github.com/appscode/voyager/client/clientset/versioned/typed/voyager/v1beta1/util"
+	"github.com/appscode/voyager/pkg/eventer"
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/cert"
+)
+
+const (
+	// tlsSecretCheckInterval is how often TLS Secrets referred by Ingresses are checked.
+	tlsSecretCheckInterval = time.Hour
+	// tlsSecretExpiryWarning is how long before expiry warnings are reported for a TLS Secret.
+	// Unlike Certificate crds, these are renewed by users, so they are warned well ahead.
+	tlsSecretExpiryWarning = 14 * 24 * time.Hour
+)
+
+var (
+	tlsSecretExpiryDesc = prometheus.NewDesc(
+		"voyager_tls_secret_expiration_timestamp_seconds",
+		"Expiry time of the certificate in a TLS Secret referred by an Ingress, in seconds since epoch.",
+		[]string{"namespace", "ingress", "secret"}, nil,
+	)
+	tlsSecretValidDesc = prometheus.NewDesc(
+		"voyager_tls_secret_valid",
+		"1 if the certificate in a TLS Secret referred by an Ingress is valid for its hosts, 0 otherwise.",
+		[]string{"namespace", "ingress", "secret"}, nil,
+	)
+
+	tlsSecretMetrics = &tlsSecretCollector{}
+)
+
+func init() {
+	prometheus.MustRegister(tlsSecretMetrics)
+}
+
+type tlsSecretResult struct {
+	namespace string
+	ingress   string
+	status    api.IngressTLSStatus
+}
+
+// tlsSecretCollector exports the results of the last check of TLS Secrets, keyed by tlsIngressKey.
+type tlsSecretCollector struct {
+	mu      sync.Mutex
+	results map[string][]tlsSecretResult
+}
+
+func (c *tlsSecretCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- tlsSecretExpiryDesc
+	ch <- tlsSecretValidDesc
+}
+
+func (c *tlsSecretCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, results := range c.results {
+		for _, r := range results {
+			if r.status.NotAfter != nil {
+				ch <- prometheus.MustNewConstMetric(tlsSecretExpiryDesc, prometheus.GaugeValue,
+					float64(r.status.NotAfter.Unix()), r.namespace, r.ingress, r.status.SecretName)
+			}
+			valid := 0.0
+			if r.status.Valid {
+				valid = 1
+			}
+			ch <- prometheus.MustNewConstMetric(tlsSecretValidDesc, prometheus.GaugeValue,
+				valid, r.namespace, r.ingress, r.status.SecretName)
+		}
+	}
+}
+
+// set replaces the results of an Ingress.
+func (c *tlsSecretCollector) set(key string, results []tlsSecretResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.results == nil {
+		c.results = map[string][]tlsSecretResult{}
+	}
+	if len(results) == 0 {
+		delete(c.results, key)
+		return
+	}
+	c.results[key] = results
+}
+
+// reset replaces the results of all Ingresses.
+func (c *tlsSecretCollector) reset(results map[string][]tlsSecretResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results = results
+}
+
+// tlsIngressKey identifies an Ingress of either api schema.
+func tlsIngressKey(ing *api.Ingress) string {
+	return ing.APISchema() + "/" + ing.Namespace + "/" + ing.Name
+}
+
+// runTLSSecretMonitor periodically checks the TLS Secrets of all Ingresses. Changes to a Secret, Certificate or
+// Ingress are checked right away, only for the Ingresses referring to it, via checkIngressesTLS.
+func (op *Operator) runTLSSecretMonitor(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(tlsSecretCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		op.checkTLSSecrets()
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkTLSSecrets checks the TLS Secrets and Certificates of all Ingresses.
+func (op *Operator) checkTLSSecrets() {
+	items, err := op.listIngresses()
+	if err != nil {
+		glog.Errorln(err)
+		return
+	}
+
+	now := time.Now()
+	results := map[string][]tlsSecretResult{}
+	for i := range items {
+		ing := items[i].DeepCopy()
+		ing.Migrate()
+		if ing.DeletionTimestamp != nil || !ing.ShouldHandleIngress(op.IngressClass) {
+			continue
+		}
+		key := tlsIngressKey(ing)
+		if r := op.checkIngressTLS(ing, now); len(r) > 0 {
+			results[key] = r
+		}
+	}
+	tlsSecretMetrics.reset(results)
+	op.pruneTLSEvents(items)
+}
+
+// checkIngressesTLS checks the TLS Secrets and Certificates of the given Ingresses, ie, the Ingresses
+// referring to a changed Secret or Certificate.
+func (op *Operator) checkIngressesTLS(items []api.Ingress) {
+	now := time.Now()
+	for i := range items {
+		ing := items[i].DeepCopy()
+		ing.Migrate()
+		if ing.DeletionTimestamp != nil || !ing.ShouldHandleIngress(op.IngressClass) {
+			tlsSecretMetrics.set(tlsIngressKey(ing), nil)
+			continue
+		}
+		tlsSecretMetrics.set(tlsIngressKey(ing), op.checkIngressTLS(ing, now))
+	}
+}
+
+// checkIngressTLS checks the TLS Secrets referred by spec.tls of an Ingress, reports invalid or expiring
+// certificates via events, records their state in Ingress status and returns it for metrics. Certificates
+// referred by spec.tls that are not issued yet are reported via CertificatePending condition.
+func (op *Operator) checkIngressTLS(ing *api.Ingress, now time.Time) []tlsSecretResult {
+	tls := ing.Spec.TLS
+	if ing.SSLPassthrough() {
+		tls = nil // certificates are served by backends
+	}
+
+	var results []tlsSecretResult
+	var statuses []api.IngressTLSStatus
+	var pending []string
+	for _, t := range tls {
+		if t.Ref == nil || t.Ref.Name == "" {
+			continue
+		}
+		if strings.EqualFold(t.Ref.Kind, api.ResourceKindCertificate) {
+			if op.isCertificatePending(ing.Namespace, t.Ref.Name) {
+				pending = append(pending, t.Ref.Name)
+			}
+			continue
+		}
+		if !(t.Ref.Kind == "" || strings.EqualFold(t.Ref.Kind, "Secret")) {
+			continue
+		}
+		var status api.IngressTLSStatus
+		secret, err := op.secretLister.Secrets(ing.Namespace).Get(t.Ref.Name)
+		if err != nil {
+			status = api.IngressTLSStatus{SecretName: t.Ref.Name, Message: err.Error()}
+		} else {
+			status = checkTLSSecret(secret, t.Hosts, now)
+		}
+		statuses = append(statuses, status)
+		results = append(results, tlsSecretResult{namespace: ing.Namespace, ingress: ing.Name, status: status})
+
+		switch {
+		case !status.Valid:
+			op.recordTLSEvent(ing, status.SecretName, eventer.EventReasonIngressTLSSecretInvalid,
+				fmt.Sprintf("TLS secret %s is invalid. Reason: %s", status.SecretName, status.Message))
+		case status.Message != "":
+			op.recordTLSEvent(ing, status.SecretName, eventer.EventReasonIngressTLSSecretExpiring,
+				fmt.Sprintf("TLS secret %s: %s", status.SecretName, status.Message))
+		default:
+			op.recordTLSEvent(ing, status.SecretName, "", "")
+		}
+	}
+
+	if len(pending) > 0 {
+		op.recordTLSEvent(ing, "", eventer.EventReasonIngressCertificatePending,
+			fmt.Sprintf("Serving self-signed placeholder certificates until Certificates %s are issued", strings.Join(pending, ", ")))
+	} else {
+		op.recordTLSEvent(ing, "", "", "")
+	}
+
+	if ing.APISchema() == api.APISchemaEngress {
+		if err := op.updateTLSStatus(ing.Namespace, ing.Name, statuses, pending); err != nil {
+			glog.Errorf("failed to update tls status of Ingress %s/%s. Reason: %v", ing.Namespace, ing.Name, err)
+		}
+	}
+	return results
+}
+
+// recordTLSEvent records an event about a TLS Secret of an Ingress, or about its pending Certificates if
+// secretName is empty, unless the same message was recorded last time. An empty message clears the last
+// one, so the event is recorded again if the problem recurs. Pending Certificates are reported as Normal events.
+func (op *Operator) recordTLSEvent(ing *api.Ingress, secretName, reason, message string) {
+	key := tlsIngressKey(ing)
+	op.tlsEventsLock.Lock()
+	last, found := op.tlsEvents[key][secretName]
+	if message == "" {
+		delete(op.tlsEvents[key], secretName)
+		if len(op.tlsEvents[key]) == 0 {
+			delete(op.tlsEvents, key)
+		}
+	} else {
+		if op.tlsEvents[key] == nil {
+			op.tlsEvents[key] = map[string]string{}
+		}
+		op.tlsEvents[key][secretName] = message
+	}
+	op.tlsEventsLock.Unlock()
+
+	if message == "" || (found && last == message) {
+		return
+	}
+	eventType := core.EventTypeWarning
+	if secretName == "" {
+		eventType = core.EventTypeNormal
+	}
+	op.recorder.Event(ing.ObjectReference(), eventType, reason, message)
+}
+
+// pruneTLSEvents forgets the events recorded for Ingresses that no longer exist.
+func (op *Operator) pruneTLSEvents(items []api.Ingress) {
+	keys := sets.NewString()
+	for i := range items {
+		keys.Insert(tlsIngressKey(&items[i]))
+	}
+	op.tlsEventsLock.Lock()
+	defer op.tlsEventsLock.Unlock()
+	for key := range op.tlsEvents {
+		if !keys.Has(key) {
+			delete(op.tlsEvents, key)
+		}
+	}
+}
+
+// updateTLSStatus sets status.tls, CertificatePending and CertificatesReady conditions of a voyager Ingress. extensions/v1beta1
+// Ingresses have no such fields, so their TLS Secrets and Certificates are reported only via events and metrics.
+func (op *Operator) updateTLSStatus(namespace, name string, statuses []api.IngressTLSStatus, pending []string) error {
+	cur, err := op.engLister.Ingresses(namespace).Get(name)
+	if err != nil {
+		return err
+	}
+	_, err = util.UpdateIngressStatus(op.VoyagerClient.VoyagerV1beta1(), cur, func(in *api.IngressStatus) *api.IngressStatus {
+		now := metav1.Now()
+		in.TLS = statuses
+		in.Conditions = setCertificatePendingCondition(in.Conditions, pending, now)
+		in.Conditions = api.SetIngressCondition(in.Conditions, certificatesReadyCondition(statuses, pending), now)
+		return in
+	}, voyager.EnableStatusSubresource)
+	return err
+}
+
+// certificatesReadyCondition returns CertificatesReady condition, which is false while a TLS Secret
+// is invalid or a Certificate is pending. Certificates that expire soon are still ready.
+func certificatesReadyCondition(statuses []api.IngressTLSStatus, pending []string) api.IngressCondition {
+	var invalid []string
+	for _, status := range statuses {
+		if !status.Valid {
+			invalid = append(invalid, status.SecretName+": "+status.Message)
+		}
+	}
+	switch {
+	case len(invalid) > 0:
+		return api.IngressCondition{
+			Type:    api.IngressCertificatesReady,
+			Status:  core.ConditionFalse,
+			Reason:  "TLSSecretInvalid",
+			Message: "Invalid TLS secrets " + strings.Join(invalid, "; "),
+		}
+	case len(pending) > 0:
+		return api.IngressCondition{
+			Type:    api.IngressCertificatesReady,
+			Status:  core.ConditionFalse,
+			Reason:  "CertificatesNotIssued",
+			Message: "Certificates " + strings.Join(pending, ", ") + " are not issued yet",
+		}
+	}
+	return api.IngressCondition{
+		Type:    api.IngressCertificatesReady,
+		Status:  core.ConditionTrue,
+		Reason:  "CertificatesReady",
+		Message: "All TLS secrets and Certificates are ready",
+	}
+}
+
+// isCertificatePending returns true if the Certificate does not exist or has no issued certificate stored yet.
+func (op *Operator) isCertificatePending(namespace, name string) bool {
+	crd, err := op.crtLister.Certificates(namespace).Get(name)
+	if err != nil {
+		return true
+	}
+	if crd.Spec.Storage.Vault != nil {
+		return crd.Status.LastIssuedCertificate == nil
+	}
+	secret, err := op.secretLister.Secrets(namespace).Get(crd.SecretName())
+	if err != nil {
+		return true
+	}
+	_, found := secret.Data[core.TLSCertKey]
+	return !found
+}
+
+// setCertificatePendingCondition returns conditions with CertificatePending condition set for pending Certificates.
+// The condition is added only when a Certificate is pending, and set to False once all are issued.
+func setCertificatePendingCondition(conditions []api.IngressCondition, pending []string, now metav1.Time) []api.IngressCondition {
+	cond := api.IngressCondition{
+		Type:    api.IngressCertificatePending,
+		Status:  core.ConditionFalse,
+		Reason:  "CertificatesIssued",
+		Message: "All Certificates are issued",
+	}
+	if len(pending) > 0 {
+		cond.Status = core.ConditionTrue
+		cond.Reason = "CertificatesNotIssued"
+		cond.Message = "Serving self-signed placeholder certificates until Certificates " + strings.Join(pending, ", ") + " are issued"
+	}
+
+	result := make([]api.IngressCondition, 0, len(conditions)+1)
+	found := false
+	for _, c := range conditions {
+		if c.Type == api.IngressCertificatePending {
+			found = true
+			cond.LastTransitionTime = c.LastTransitionTime
+			if c.Status != cond.Status {
+				cond.LastTransitionTime = now
+			}
+			c = cond
+		}
+		result = append(result, c)
+	}
+	if !found {
+		if len(pending) == 0 {
+			return conditions
+		}
+		cond.LastTransitionTime = now
+		result = append(result, cond)
+	}
+	return result
+}
+
+// checkTLSSecret verifies that the certificate in a TLS Secret matches its private key, is not
+// expired and covers hosts. Status has a message if the certificate is invalid or expires soon.
+func checkTLSSecret(secret *core.Secret, hosts []string, now time.Time) api.IngressTLSStatus {
+	status := api.IngressTLSStatus{SecretName: secret.Name}
+
+	crtData, found := secret.Data[core.TLSCertKey]
+	if !found {
+		status.Message = "missing " + core.TLSCertKey
+		return status
+	}
+	keyData, found := secret.Data[core.TLSPrivateKeyKey]
+	if !found {
+		status.Message = "missing " + core.TLSPrivateKeyKey
+		return status
+	}
+	certs, err := cert.ParseCertsPEM(crtData)
+	if err != nil {
+		status.Message = fmt.Sprintf("failed to parse %s: %v", core.TLSCertKey, err)
+		return status
+	}
+	crt := certs[0]
+	notAfter := metav1.NewTime(crt.NotAfter)
+	status.NotAfter = &notAfter
+
+	if _, err := tls.X509KeyPair(crtData, keyData); err != nil {
+		status.Message = fmt.Sprintf("certificate does not match private key: %v", err)
+		return status
+	}
+	if now.After(crt.NotAfter) {
+		status.Message = fmt.Sprintf("certificate expired at %s", crt.NotAfter.UTC().Format(time.RFC3339))
+		return status
+	}
+	if now.Before(crt.NotBefore) {
+		status.Message = fmt.Sprintf("certificate is not valid before %s", crt.NotBefore.UTC().Format(time.RFC3339))
+		return status
+	}
+	if missing := uncoveredHosts(crt, hosts); len(missing) > 0 {
+		status.Message = fmt.Sprintf("certificate does not cover hosts %s", strings.Join(missing, ", "))
+		return status
+	}
+
+	status.Valid = true
+	if crt.NotAfter.Sub(now) < tlsSecretExpiryWarning {
+		status.Message = fmt.Sprintf("certificate expires at %s", crt.NotAfter.UTC().Format(time.RFC3339))
+	}
+	return status
+}
+
+// uncoveredHosts returns the hosts that are not in the subject alternative names of crt.
+func uncoveredHosts(crt *x509.Certificate, hosts []string) []string {
+	var missing []string
+	for _, host := range hosts {
+		if host == "" {
+			continue
+		}
+		if err := crt.VerifyHostname(host); err != nil {
+			missing = append(missing, host)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,160 @@
+package operator
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	api "github.com/appscode/voyager/apis/voyager/v1beta1"
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	core_listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func newTLSSecret(t *testing.T, notAfter time.Time, dnsNames ...string) *core.Secret {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:     dnsNames,
+		NotBefore:    notAfter.Add(-90 * 24 * time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &core.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "default"},
+		Data: map[string][]byte{
+			core.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			core.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		},
+	}
+}
+
+func TestCheckTLSSecret(t *testing.T) {
+	now := time.Now()
+
+	secret := newTLSSecret(t, now.Add(60*24*time.Hour), "example.com", "*.example.com")
+	status := checkTLSSecret(secret, []string{"example.com", "www.example.com"}, now)
+	assert.True(t, status.Valid)
+	assert.Empty(t, status.Message)
+	if assert.NotNil(t, status.NotAfter) {
+		assert.Equal(t, now.Add(60*24*time.Hour).Unix(), status.NotAfter.Unix())
+	}
+
+	status = checkTLSSecret(secret, []string{"example.org"}, now)
+	assert.False(t, status.Valid)
+	assert.Contains(t, status.Message, "example.org")
+
+	// expires soon
+	status = checkTLSSecret(newTLSSecret(t, now.Add(3*24*time.Hour), "example.com"), []string{"example.com"}, now)
+	assert.True(t, status.Valid)
+	assert.Contains(t, status.Message, "expires")
+
+	// expired
+	status = checkTLSSecret(newTLSSecret(t, now.Add(-time.Hour), "example.com"), []string{"example.com"}, now)
+	assert.False(t, status.Valid)
+	assert.Contains(t, status.Message, "expired")
+
+	// key of another certificate
+	other := newTLSSecret(t, now.Add(60*24*time.Hour), "example.com")
+	secret.Data[core.TLSPrivateKeyKey] = other.Data[core.TLSPrivateKeyKey]
+	status = checkTLSSecret(secret, []string{"example.com"}, now)
+	assert.False(t, status.Valid)
+	assert.Contains(t, status.Message, "private key")
+}
+
+func TestSetCertificatePendingCondition(t *testing.T) {
+	t1 := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	t2 := metav1.NewTime(time.Now().Truncate(time.Second))
+
+	assert.Nil(t, setCertificatePendingCondition(nil, nil, t1))
+
+	conditions := setCertificatePendingCondition(nil, []string{"example"}, t1)
+	if assert.Len(t, conditions, 1) {
+		assert.Equal(t, core.ConditionTrue, conditions[0].Status)
+		assert.Contains(t, conditions[0].Message, "example")
+		assert.Equal(t, t1, conditions[0].LastTransitionTime)
+	}
+
+	// still pending
+	conditions = setCertificatePendingCondition(conditions, []string{"example", "other"}, t2)
+	if assert.Len(t, conditions, 1) {
+		assert.Contains(t, conditions[0].Message, "other")
+		assert.Equal(t, t1, conditions[0].LastTransitionTime)
+	}
+
+	conditions = setCertificatePendingCondition(conditions, nil, t2)
+	if assert.Len(t, conditions, 1) {
+		assert.Equal(t, core.ConditionFalse, conditions[0].Status)
+		assert.Equal(t, t2, conditions[0].LastTransitionTime)
+	}
+}
+
+func TestCheckIngressesTLS(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	op := &Operator{
+		recorder:     recorder,
+		secretLister: core_listers.NewSecretLister(indexer),
+		tlsEvents:    map[string]map[string]string{},
+	}
+	ing := api.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "default",
+			Annotations: map[string]string{api.APISchema: api.APISchemaIngress},
+		},
+		Spec: api.IngressSpec{TLS: []api.IngressTLS{
+			{Hosts: []string{"example.com"}, Ref: &api.LocalTypedReference{Kind: "Secret", Name: "tls"}},
+		}},
+	}
+	events := func() int {
+		n := 0
+		for {
+			select {
+			case <-recorder.Events:
+				n++
+			default:
+				return n
+			}
+		}
+	}
+
+	// reported once while the secret stays invalid
+	assert.NoError(t, indexer.Add(newTLSSecret(t, time.Now().Add(-time.Hour), "example.com")))
+	op.checkIngressesTLS([]api.Ingress{ing})
+	op.checkIngressesTLS([]api.Ingress{ing})
+	assert.Equal(t, 1, events())
+
+	assert.NoError(t, indexer.Update(newTLSSecret(t, time.Now().Add(60*24*time.Hour), "example.com")))
+	op.checkIngressesTLS([]api.Ingress{ing})
+	assert.Equal(t, 0, events())
+
+	// reported again once it recurs
+	assert.NoError(t, indexer.Update(newTLSSecret(t, time.Now().Add(-time.Hour), "example.com")))
+	op.checkIngressesTLS([]api.Ingress{ing})
+	assert.Equal(t, 1, events())
+
+	op.pruneTLSEvents(nil)
+	assert.Empty(t, op.tlsEvents)
+}